@@ -3,11 +3,15 @@ package main
 import (
 	"flag"
 	"fmt"
+	"image/color"
 	"os"
+	"strings"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/nevisdale/go-chip8/internal/beep"
 	"github.com/nevisdale/go-chip8/internal/chip8"
 	"github.com/nevisdale/go-chip8/internal/renderer"
+	"github.com/nevisdale/go-chip8/internal/terminal"
 )
 
 var (
@@ -16,16 +20,100 @@ var (
 	fgColorHex  string
 	bgColorHex  string
 	tps         int
+	ipf         int
+	maxIPS      int
+	wavPath     string
+	rewindSecs  int
+	diagnostics bool
+	disasm      bool
+	keypad      string
+	idleTPS     int
+	keyWait     string
+	ghostFrames int
+	deflicker   bool
+	selfTest    bool
+	overrun     string
+	strict      bool
+	stackSize   int
+	flagsDir    string
+	thumbnail   string
+	frames      int
+	headless    int
+	ramSeed     uint64
+	wrap        bool
+	watch       bool
+	trace       string
+	quirks      string
+	wave        string
+	beepHz      float64
+	shotDir     string
+	shotScale   int
+	gifFPS      int
+	gifSecs     int
+	fontPath    string
+	scale       int
+	fullscreen  bool
+	termMode    bool
+	keys        string
+	turbo       int
+	background  bool
 )
 
 func main() {
-	flag.StringVar(&romPath, "f", "", "rom file. is required")
+	flag.StringVar(&romPath, "f", "", "rom file or http(s) url to download it from. is required")
 	flag.StringVar(&fgColorHex, "fg", "FFFFFFFF", "rgba foreground color in hex. white is default")
 	flag.StringVar(&bgColorHex, "bg", "000000FF", "rgba background color in hex. black is default")
 	flag.IntVar(&tps, "tps", 60, "tps")
+	flag.IntVar(&maxIPS, "maxips", 0, "max instructions per second regardless of tps, e.g. 500 for the original speed. 0 is unlimited")
+	flag.IntVar(&ipf, "ipf", 0, "instructions per frame. overrides tps. the known speed of the rom is used by default")
 	flag.Float64Var(&soundVolume, "volume", 0.5, "sound volume. must be between 0 and 1")
+	flag.Float64Var(&beepHz, "beep-hz", 440, "beep frequency in Hz")
+	flag.StringVar(&wave, "wave", "sine", "beep waveform: sine, square, triangle or sawtooth")
+	flag.StringVar(&wavPath, "wav", "", "write the sound to a wav file instead of speakers")
+	flag.IntVar(&turbo, "turbo", 4, "how many times faster the game runs while Tab is held")
+	flag.BoolVar(&background, "background", false, "run the emulation in the background at the tps instead of in the frames of the window")
+	flag.IntVar(&idleTPS, "idle-tps", 10, "updates per second while the game is paused or jumps to itself with the timers stopped. 0 disables throttling")
+	flag.IntVar(&ghostFrames, "ghost", 0, "frames an erased pixel keeps fading out, for fast sprites. 0 disables the trail")
+	flag.BoolVar(&deflicker, "deflicker", false, "hide the blank frame of games that clear the screen before redrawing it")
+	flag.BoolVar(&termMode, "terminal", false, "draw the game in the terminal instead of a window, e.g. over ssh. Escape quits")
+	flag.BoolVar(&fullscreen, "fullscreen", false, "start in fullscreen. F11 switches it")
+	flag.IntVar(&scale, "scale", 10, "window pixels per screen pixel")
+	flag.StringVar(&keys, "keys", "", "comma separated keyboard keys of chip8 keys, e.g. 4=A,7=Q for AZERTY. the other keys keep the default layout")
+	flag.StringVar(&keypad, "keypad", "below", "keypad window placement: below or right")
+	flag.StringVar(&keyWait, "keywait", "lowest", "key stored by FX0A when several keys are pressed: lowest or latest")
+	flag.StringVar(&flagsDir, "flags", "", "directory to keep the SCHIP flag registers (high scores) of roms in between runs. empty disables it")
+	flag.BoolVar(&wrap, "wrap", false, "wrap sprites around the screen edges instead of clipping them")
+	flag.StringVar(&quirks, "quirks", "", "comma separated quirks of the original interpreter to enable: "+
+		strings.Join(chip8.QuirkNames(), ", ")+", or the profiles "+strings.Join(chip8.QuirkProfileNames(), ", "))
+	flag.StringVar(&fontPath, "font", "", "file of the 80 byte font of the hex digits, 5 bytes per digit from 0 to F. the built-in font is default")
+	flag.StringVar(&overrun, "overrun", "halt", "what FX55/FX65, FX33 and DXYN do past the end of ram: halt, wrap or clamp")
+	flag.IntVar(&stackSize, "stack", 16, "levels of nested subroutines, up to 255 for deeply recursive roms")
+	flag.BoolVar(&strict, "strict", false, "halt on unknown opcodes, e.g. 5XYN with N other than 0, instead of skipping them")
+	flag.StringVar(&shotDir, "screenshots", "", "directory of the screenshots taken with F2 and the gifs recorded with F4. the working directory is default")
+	flag.IntVar(&shotScale, "screenshot-scale", 10, "size in pixels of a screen pixel in screenshots")
+	flag.IntVar(&gifFPS, "gif-fps", 20, "frame rate of the gifs recorded with F4")
+	flag.IntVar(&gifSecs, "gif-max", 30, "max seconds of a gif, the recording stops when it's reached")
+	flag.StringVar(&thumbnail, "thumbnail", "", "run the rom without a window and write a png of the screen after -frames frames to the file, then exit")
+	flag.IntVar(&frames, "frames", 60, "frames to run the rom for a thumbnail")
+	flag.IntVar(&headless, "headless", 0, "run the rom without a window for at most the instructions, print the screen as text and exit. 0 opens a window")
+	flag.Uint64Var(&ramSeed, "ramseed", 0, "fill the free ram with random bytes of the seed like real hardware. 0 keeps it zeroed")
+	flag.BoolVar(&watch, "watch", false, "restart the game when the rom file changes on disk")
+	flag.BoolVar(&selfTest, "selftest", false, "run the built-in self-test rom, report the result and exit")
+	flag.BoolVar(&disasm, "disasm", false, "print the disassembly of the rom and exit")
+	flag.StringVar(&trace, "trace", "", "trace the executed instructions to stdout: all or comma separated mnemonics, e.g. DRW,LD. empty disables tracing")
+	flag.BoolVar(&diagnostics, "diag", false, "report suspicious rom behavior to stderr")
+	flag.IntVar(&rewindSecs, "rewind", 10, "seconds of gameplay that can be rewound. 0 disables rewinding")
 	flag.Parse()
 
+	if selfTest {
+		if err := chip8.SelfTest(); err != nil {
+			fmt.Fprintf(os.Stderr, "self-test failed: %s\n", err.Error())
+			os.Exit(1)
+		}
+		fmt.Println("self-test passed")
+		os.Exit(0)
+	}
+
 	if len(romPath) == 0 {
 		fmt.Fprintf(os.Stderr, "rom file is empty\n")
 		os.Exit(1)
@@ -47,32 +135,328 @@ func main() {
 		os.Exit(1)
 	}
 
-	rom, err := chip8.NewRomFromFile(romPath)
+	var waveform beep.Waveform
+	switch wave {
+	case "sine":
+		waveform = beep.Sine
+	case "square":
+		waveform = beep.Square
+	case "triangle":
+		waveform = beep.Triangle
+	case "sawtooth":
+		waveform = beep.Sawtooth
+	default:
+		fmt.Fprintf(os.Stderr, "waveform %s is invalid, must be sine, square, triangle or sawtooth\n", wave)
+		os.Exit(1)
+	}
+
+	var keypadPlacement renderer.KeypadPlacement
+	switch keypad {
+	case "below":
+		keypadPlacement = renderer.KeypadBelow
+	case "right":
+		keypadPlacement = renderer.KeypadRight
+	default:
+		fmt.Fprintf(os.Stderr, "keypad placement %s is invalid, must be below or right\n", keypad)
+		os.Exit(1)
+	}
+
+	var keyMap map[uint8]ebiten.Key
+	if len(keys) > 0 {
+		keyMap, err = renderer.ParseKeyMap(keys)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't parse the keys: %s\n", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	var keyWaitPolicy chip8.KeyWaitPolicy
+	switch keyWait {
+	case "lowest":
+		keyWaitPolicy = chip8.KeyWaitLowest
+	case "latest":
+		keyWaitPolicy = chip8.KeyWaitLatest
+	default:
+		fmt.Fprintf(os.Stderr, "key wait policy %s is invalid, must be lowest or latest\n", keyWait)
+		os.Exit(1)
+	}
+
+	var overrunPolicy chip8.MemoryOverrunPolicy
+	switch overrun {
+	case "halt":
+		overrunPolicy = chip8.OverrunHalt
+	case "wrap":
+		overrunPolicy = chip8.OverrunWrap
+	case "clamp":
+		overrunPolicy = chip8.OverrunClamp
+	default:
+		fmt.Fprintf(os.Stderr, "overrun policy %s is invalid, must be halt, wrap or clamp\n", overrun)
+		os.Exit(1)
+	}
+
+	romQuirks, err := chip8.ParseQuirks(quirks)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "couldn't creare a rom from the file: %s\n", err.Error())
+		fmt.Fprintf(os.Stderr, "%s, must be one of %s or a profile %s\n", err.Error(),
+			strings.Join(chip8.QuirkNames(), ", "), strings.Join(chip8.QuirkProfileNames(), ", "))
 		os.Exit(1)
 	}
 
-	beepPlayer, err := beep.New()
+	// -wrap is a shorthand for the wrap quirk
+	romQuirks.SpriteWrapping = romQuirks.SpriteWrapping || wrap
+
+	romFromURL := strings.HasPrefix(romPath, "http://") || strings.HasPrefix(romPath, "https://")
+	if romFromURL && watch {
+		fmt.Fprintf(os.Stderr, "a rom downloaded from a url can't be watched\n")
+		os.Exit(1)
+	}
+
+	var rom chip8.Rom
+	if romFromURL {
+		rom, err = chip8.NewRomFromURL(romPath)
+	} else {
+		rom, err = chip8.NewRomFromFile(romPath)
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "beep player: %s\n", err.Error())
+		fmt.Fprintf(os.Stderr, "couldn't create a rom: %s\n", err.Error())
 		os.Exit(1)
 	}
-	beepPlayer.SetVolume(soundVolume)
 
-	chip8 := chip8.NewChip8()
-	chip8.LoadRom(rom)
-	chip8.SetTPS(tps)
-	chip8.SetSoundPlayer(beepPlayer)
+	if disasm {
+		fmt.Print(chip8.Disassemble(rom))
+		os.Exit(0)
+	}
 
-	renderer := renderer.NewFromConfig(&chip8, renderer.Config{
-		FgColor: fgColor,
-		BgColor: bgColor,
+	// the known speed of the rom is used only if the speed isn't set explicitly
+	speedIsSet := false
+	flag.Visit(func(f *flag.Flag) {
+		speedIsSet = speedIsSet || f.Name == "tps" || f.Name == "ipf"
 	})
-	if err := renderer.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "couldn't run a renderer: %s\n", err.Error())
+	if !speedIsSet {
+		ipf, _ = chip8.LookupSpeed(rom.Hash())
+	}
+	if ipf > chip8.MaxCyclesPerFrame {
+		fmt.Fprintf(os.Stderr, "%d instructions per frame are too many, max is %d\n", ipf, chip8.MaxCyclesPerFrame)
+		os.Exit(1)
+	}
+	if ipf > 0 {
+		tps = ipf * chip8.FramesPerSecond
+	}
+
+	if len(thumbnail) > 0 {
+		if err := writeThumbnail(rom, romQuirks, overrunPolicy, fgColor, bgColor); err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't write a thumbnail: %s\n", err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if headless > 0 {
+		if err := runHeadless(rom, romQuirks, overrunPolicy); err != nil {
+			fmt.Fprintf(os.Stderr, "headless run failed: %s\n", err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	var flagStorage chip8.FlagStorage
+	if len(flagsDir) > 0 {
+		flagStorage = chip8.NewFlagFile(flagsDir, rom)
+	}
+
+	chip8, err := chip8.NewChip8WithOptions(
+		chip8.WithTPS(tps),
+		chip8.WithQuirks(romQuirks),
+		chip8.WithMemoryOverrunPolicy(overrunPolicy),
+		chip8.WithStrictMode(strict),
+		chip8.WithStackSize(stackSize),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't create the machine: %s\n", err.Error())
+		os.Exit(1)
+	}
+	if err := chip8.LoadRom(rom); err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't load the rom: %s\n", err.Error())
 		os.Exit(1)
 	}
+	chip8.SetMaxIPS(maxIPS)
+	chip8.EnableRewind(rewindSecs)
+	chip8.SetKeyWaitPolicy(keyWaitPolicy)
+	if len(fontPath) > 0 {
+		font, err := os.ReadFile(fontPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't read the font: %s\n", err.Error())
+			os.Exit(1)
+		}
+		if err := chip8.SetFont(font); err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't set the font: %s\n", err.Error())
+			os.Exit(1)
+		}
+	}
+	if len(trace) > 0 {
+		chip8.SetTraceWriter(os.Stdout)
+		if trace != "all" {
+			chip8.SetTraceFilter(strings.Split(trace, ",")...)
+		}
+	}
+	if ramSeed != 0 {
+		chip8.SetRandomizeRAM(ramSeed)
+	}
+	if diagnostics {
+		chip8.SetDiagnosticWriter(os.Stderr)
+	}
+	if flagStorage != nil {
+		if err := chip8.SetFlagStorage(flagStorage); err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't load the flags: %s\n", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	// the player is set up last, so the wav file is closed on every path from here
+	var wavFile *os.File
+	var wavRecorder *beep.WAVRecorder
+	if len(wavPath) > 0 {
+		wavFile, err = os.Create(wavPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't create a wav file: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		// the recording follows the emulated frames
+		wavRecorder = beep.NewWAVRecorder(wavFile, func() uint64 { return chip8.FrameCount() })
+		wavRecorder.SetWaveform(waveform)
+		if err := wavRecorder.SetFrequency(beepHz); err != nil {
+			wavFile.Close()
+			fmt.Fprintf(os.Stderr, "beep frequency: %s\n", err.Error())
+			os.Exit(1)
+		}
+		chip8.SetSoundPlayer(wavRecorder)
+	} else {
+		beepPlayer, err := beep.NewWithWaveform(waveform)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "beep player: %s\n", err.Error())
+			os.Exit(1)
+		}
+		if err := beepPlayer.SetFrequency(beepHz); err != nil {
+			fmt.Fprintf(os.Stderr, "beep frequency: %s\n", err.Error())
+			os.Exit(1)
+		}
+		beepPlayer.SetVolume(soundVolume)
+		chip8.SetSoundPlayer(beepPlayer)
+	}
+
+	var watchPath string
+	if watch {
+		watchPath = romPath
+	}
+
+	exitCode := 0
+	if termMode {
+		if err := terminal.New(&chip8, os.Stdin, os.Stdout).Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't run a terminal renderer: %s\n", err.Error())
+			exitCode = 1
+		}
+	} else {
+		renderer := renderer.NewFromConfig(&chip8, renderer.Config{
+			Palette: renderer.Palette{bgColor, fgColor},
+
+			KeypadPlacement: keypadPlacement,
+			KeyMap:          keyMap,
+			Scale:           scale,
+			Fullscreen:      fullscreen,
+			IdleTPS:         idleTPS,
+			TurboMultiplier: turbo,
+			Background:      background,
+
+			GhostTrailFrames: ghostFrames,
+			FlickerReduction: deflicker,
+
+			WatchRomPath: watchPath,
+
+			ScreenshotDir:   shotDir,
+			ScreenshotScale: shotScale,
+
+			GifFPS:        gifFPS,
+			GifMaxSeconds: gifSecs,
+		})
+		if err := renderer.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't run a renderer: %s\n", err.Error())
+			exitCode = 1
+		}
+	}
+
+	if err := chip8.SaveFlags(); err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't save the flags: %s\n", err.Error())
+		exitCode = 1
+	}
+
+	if wavRecorder != nil {
+		if err := closeWAV(wavRecorder, wavFile); err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't write the wav file: %s\n", err.Error())
+			exitCode = 1
+		}
+	}
+
+	os.Exit(exitCode)
+}
+
+// closeWAV writes the recording to the file and closes it, the file is closed even if the recording can't be written.
+func closeWAV(recorder *beep.WAVRecorder, file *os.File) error {
+	err := recorder.Close()
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close wav file: %w", closeErr)
+	}
+	return err
+}
+
+// thumbnails are scaled up like the screenshots copied to the clipboard
+const thumbnailScale = 10
+
+// newWindowlessMachine returns the machine of the rom for the runs without a window
+func newWindowlessMachine(rom chip8.Rom, quirks chip8.Quirks, overrunPolicy chip8.MemoryOverrunPolicy) (chip8.Chip8, error) {
+	machine, err := chip8.NewChip8WithOptions(
+		chip8.WithTPS(tps),
+		chip8.WithQuirks(quirks),
+		chip8.WithMemoryOverrunPolicy(overrunPolicy),
+		chip8.WithStrictMode(strict),
+		chip8.WithStackSize(stackSize),
+	)
+	if err != nil {
+		return chip8.Chip8{}, err
+	}
+	if err := machine.LoadRom(rom); err != nil {
+		return chip8.Chip8{}, err
+	}
+	if ramSeed != 0 {
+		machine.SetRandomizeRAM(ramSeed)
+	}
+	return machine, nil
+}
+
+func writeThumbnail(rom chip8.Rom, quirks chip8.Quirks, overrunPolicy chip8.MemoryOverrunPolicy, fgColor, bgColor color.Color) error {
+	machine, err := newWindowlessMachine(rom, quirks, overrunPolicy)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(thumbnail)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer f.Close()
+
+	if err := machine.WriteThumbnail(f, frames, fgColor, bgColor, thumbnailScale); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func runHeadless(rom chip8.Rom, quirks chip8.Quirks, overrunPolicy chip8.MemoryOverrunPolicy) error {
+	machine, err := newWindowlessMachine(rom, quirks, overrunPolicy)
+	if err != nil {
+		return err
+	}
 
-	os.Exit(0)
+	err = machine.RunHeadless(headless)
+	fmt.Print(machine.ScreenText())
+	return err
 }
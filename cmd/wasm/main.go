@@ -0,0 +1,68 @@
+//go:build js && wasm
+
+// Command wasm runs the emulator in a browser.
+// The page passes the rom as bytes because there are no files to read, see index.html.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall/js"
+
+	"github.com/nevisdale/go-chip8/internal/beep"
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/nevisdale/go-chip8/internal/renderer"
+)
+
+var errRomLoaded = errors.New("a rom is already loaded, reload the page to play another one")
+
+func main() {
+	roms := make(chan chip8.Rom, 1)
+
+	// chip8LoadRom(name, bytes) starts the game of the rom, bytes is a Uint8Array.
+	// It returns the error message if the rom is rejected.
+	js.Global().Set("chip8LoadRom", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) != 2 {
+			return "chip8LoadRom expects the name and the bytes of the rom"
+		}
+		rom, err := romFromJS(args[0].String(), args[1])
+		if err != nil {
+			return err.Error()
+		}
+		select {
+		case roms <- rom:
+		default:
+			return errRomLoaded.Error()
+		}
+		return nil
+	}))
+
+	rom := <-roms
+
+	beepPlayer, err := beep.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "beep player: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	// the known speed of the rom is used, the default one otherwise
+	ipf, knownSpeed := chip8.LookupSpeed(rom.Hash())
+	tps := ipf * chip8.FramesPerSecond
+
+	chip8 := chip8.NewChip8()
+	if err := chip8.LoadRom(rom); err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't load the rom: %s\n", err.Error())
+		os.Exit(1)
+	}
+	if knownSpeed {
+		chip8.SetTPS(tps)
+	}
+	chip8.SetSoundPlayer(beepPlayer)
+
+	renderer := renderer.NewFromConfig(&chip8, renderer.Config{})
+	if err := renderer.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't run a renderer: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"syscall/js"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+)
+
+var errNotUint8Array = errors.New("rom bytes must be a Uint8Array")
+
+// romFromJS copies the rom of the name out of the Uint8Array.
+// The bytes are read like a rom file, e.g. a gzip-compressed rom is decompressed.
+func romFromJS(name string, data js.Value) (chip8.Rom, error) {
+	if !data.InstanceOf(js.Global().Get("Uint8Array")) {
+		return chip8.Rom{}, errNotUint8Array
+	}
+
+	buf := make([]byte, data.Length())
+	js.CopyBytesToGo(buf, data)
+	return chip8.NewRomFromReader(name, bytes.NewReader(buf))
+}
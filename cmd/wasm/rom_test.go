@@ -0,0 +1,36 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRomFromJS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("copies the bytes", func(t *testing.T) {
+		data := js.Global().Get("Uint8Array").New(4)
+		js.CopyBytesToJS(data, []byte{0x00, 0xe0, 0x12, 0x02})
+
+		rom, err := romFromJS("game.ch8", data)
+		require.NoError(t, err)
+		require.Equal(t, chip8.Rom{Name: "game.ch8", Data: []byte{0x00, 0xe0, 0x12, 0x02}}, rom)
+	})
+
+	t.Run("rejects other values", func(t *testing.T) {
+		_, err := romFromJS("game.ch8", js.ValueOf("00e0"))
+		require.ErrorIs(t, err, errNotUint8Array)
+	})
+
+	t.Run("rejects a too large rom", func(t *testing.T) {
+		data := js.Global().Get("Uint8Array").New(4096)
+
+		_, err := romFromJS("game.ch8", data)
+		require.ErrorIs(t, err, chip8.ErrRomTooLarge)
+	})
+}
@@ -5,6 +5,8 @@ go 1.22.4
 require (
 	github.com/hajimehoshi/ebiten/v2 v2.7.6
 	github.com/stretchr/testify v1.9.0
+	golang.design/x/clipboard v0.7.0
+	golang.org/x/sys v0.20.0
 )
 
 require (
@@ -15,7 +17,9 @@ require (
 	github.com/ebitengine/purego v0.7.0 // indirect
 	github.com/jezek/xgb v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/exp v0.0.0-20190731235908-ec7cb31e5a56 // indirect
+	golang.org/x/image v0.18.0 // indirect
+	golang.org/x/mobile v0.0.0-20230301163155-e0f57694e12c // indirect
 	golang.org/x/sync v0.7.0 // indirect
-	golang.org/x/sys v0.20.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -2,9 +2,9 @@ package beep
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"log"
-	"math"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/audio"
@@ -12,40 +12,129 @@ import (
 
 const (
 	sampleRate = 44100
-	beepHz     = 440
-	duration   = time.Second
+	// the default frequency of the tone
+	beepHz = 440
+	// the highest frequency that the sample rate can represent
+	maxHz = sampleRate / 2
+	// length of the tone buffer, it's looped while the beep sounds
+	duration = time.Second
 
 	volumeStep = 0.2
 	volumeMax  = 1.0
 	volumeMin  = 0.0
+	// the rounding error of the volume steps, the volume within it of zero is zero
+	volumeEpsilon = 1e-9
 )
 
+// player is the part of the audio player that Beep uses
+type player interface {
+	Play()
+	Pause()
+	IsPlaying() bool
+	Rewind() error
+	Volume() float64
+	SetVolume(volume float64)
+	Close() error
+}
+
+var ErrInvalidFrequency = errors.New("invalid frequency")
+
 type Beep struct {
-	p *audio.Player
+	p player
+	// newPlayer returns a player that loops the tone samples
+	newPlayer func(buf []byte) (player, error)
+
+	waveform Waveform
+	hz       float64
+
+	muted bool
+	// the volume before the beep was muted
+	unmutedVolume float64
+
+	// the beep is on between Play and Stop, even while the player is paused at zero volume
+	sounding bool
 }
 
+// New returns a beep of the sine tone.
 func New() (*Beep, error) {
-	numSamples := sampleRate * int(duration.Seconds())
-	buf := make([]byte, numSamples*2)
-	for i := 0; i < numSamples; i++ {
-		a := math.Sin(2.0 * math.Pi * float64(beepHz) * float64(i) / float64(sampleRate))
-		s := int16(a * math.MaxInt16)
-		buf[2*i] = byte(s)
-		buf[2*i+1] = byte(s >> 8)
-	}
+	return NewWithWaveform(Sine)
+}
 
+// NewWithWaveform returns a beep of the tone in the waveform.
+func NewWithWaveform(w Waveform) (*Beep, error) {
 	audioCtx := audio.NewContext(sampleRate)
-	player, err := audioCtx.NewPlayer(bytes.NewReader(buf))
+	b := &Beep{
+		newPlayer: func(buf []byte) (player, error) {
+			return audioCtx.NewPlayer(audio.NewInfiniteLoop(bytes.NewReader(buf), int64(len(buf))))
+		},
+		waveform: w,
+		hz:       beepHz,
+	}
+
+	p, err := b.newPlayer(b.tone())
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create an audio player: %w", err)
 	}
+	b.p = p
+
+	return b, nil
+}
+
+// tone returns the samples of the tone buffer in the waveform and the frequency of the beep
+func (b *Beep) tone() []byte {
+	return generateTone(b.waveform, b.hz, sampleRate*int(duration.Seconds()))
+}
 
-	return &Beep{
-		p: player,
-	}, nil
+// SetFrequency sets the pitch of the beep in Hz, it must be positive and at most half the sample rate.
+// The tone is regenerated, the volume is kept and the sounding beep goes on at the new pitch.
+func (b *Beep) SetFrequency(hz float64) error {
+	if err := validateFrequency(hz); err != nil {
+		return err
+	}
+	if hz == b.hz {
+		return nil
+	}
+
+	old := b.hz
+	b.hz = hz
+	p, err := b.newPlayer(b.tone())
+	if err != nil {
+		b.hz = old
+		return fmt.Errorf("couldn't create an audio player: %w", err)
+	}
+
+	p.SetVolume(b.p.Volume())
+	playing := b.p.IsPlaying()
+	b.p.Pause()
+	if err := b.p.Close(); err != nil {
+		log.Printf("couldn't close the audio player: %s\n", err.Error())
+	}
+	b.p = p
+	if playing {
+		b.p.Play()
+	}
+	return nil
+}
+
+// Frequency returns the pitch of the beep in Hz.
+func (b *Beep) Frequency() float64 {
+	return b.hz
+}
+
+func validateFrequency(hz float64) error {
+	// the negated check also catches NaN
+	if !(hz > 0 && hz <= maxHz) {
+		return fmt.Errorf("%w %v, must be above 0 and at most %d", ErrInvalidFrequency, hz, maxHz)
+	}
+	return nil
 }
 
+// Play starts the beep, it sounds until Stop. Nothing is played at zero volume.
 func (b *Beep) Play() {
+	b.sounding = true
+	if b.p.Volume() <= volumeMin || b.p.IsPlaying() {
+		return
+	}
 	if err := b.p.Rewind(); err != nil {
 		log.Printf("couldn't rewind the audio player: %s\n", err.Error())
 		return
@@ -53,20 +142,71 @@ func (b *Beep) Play() {
 	b.p.Play()
 }
 
+// Stop ends the beep.
+func (b *Beep) Stop() {
+	b.sounding = false
+	b.p.Pause()
+}
+
 func (b *Beep) VolumeUp() {
-	volume := b.p.Volume()
-	volume = min(volume+volumeStep, volumeMax)
-	b.p.SetVolume(volume)
+	b.SetVolume(b.p.Volume() + volumeStep)
 }
 
 func (b *Beep) VolumeDown() {
-	volume := b.p.Volume()
-	volume = max(volume-volumeStep, volumeMin)
-	b.p.SetVolume(volume)
+	b.SetVolume(b.p.Volume() - volumeStep)
 }
 
+// SetVolume sets the volume between 0 and 1, the muted beep is unmuted.
+// Zero volume stops the player, so the silent beep takes no audio processing.
+// The beep that is still on when the volume is raised again, e.g. while the sound timer runs, sounds on.
 func (b *Beep) SetVolume(volume float64) {
+	b.muted = false
+
 	volume = min(volume, volumeMax)
 	volume = max(volume, volumeMin)
+
+	// steps of 0.2 don't add up to exact zero
+	if volume < volumeMin+volumeEpsilon {
+		volume = volumeMin
+	}
 	b.p.SetVolume(volume)
+
+	switch {
+	case volume == volumeMin && b.p.IsPlaying():
+		b.p.Pause()
+	case volume > volumeMin && b.sounding && !b.p.IsPlaying():
+		b.p.Play()
+	}
+}
+
+// Mute silences the beep, the volume is restored by Unmute.
+func (b *Beep) Mute() {
+	if b.muted {
+		return
+	}
+	volume := b.p.Volume()
+	b.SetVolume(volumeMin)
+	b.muted = true
+	b.unmutedVolume = volume
+}
+
+// Unmute restores the volume the beep had before Mute.
+func (b *Beep) Unmute() {
+	if !b.muted {
+		return
+	}
+	b.SetVolume(b.unmutedVolume)
+}
+
+// ToggleMute mutes the beep or unmutes the muted one.
+func (b *Beep) ToggleMute() {
+	if b.muted {
+		b.Unmute()
+	} else {
+		b.Mute()
+	}
+}
+
+func (b *Beep) IsMuted() bool {
+	return b.muted
 }
@@ -0,0 +1,199 @@
+package beep
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakePlayer struct {
+	playing bool
+	volume  float64
+	rewinds int
+	closed  bool
+
+	// the tone samples the player loops
+	buf []byte
+}
+
+func (p *fakePlayer) Play()                    { p.playing = true }
+func (p *fakePlayer) Pause()                   { p.playing = false }
+func (p *fakePlayer) IsPlaying() bool          { return p.playing }
+func (p *fakePlayer) Rewind() error            { p.rewinds++; return nil }
+func (p *fakePlayer) Volume() float64          { return p.volume }
+func (p *fakePlayer) SetVolume(volume float64) { p.volume = volume }
+func (p *fakePlayer) Close() error             { p.closed = true; return nil }
+
+func TestBeep_Volume(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero volume stops the player", func(t *testing.T) {
+		p := &fakePlayer{volume: 0.5}
+		b := &Beep{p: p}
+
+		b.Play()
+		require.True(t, p.playing)
+
+		b.SetVolume(0)
+		require.False(t, p.playing)
+
+		// beeps at zero volume aren't played
+		b.Play()
+		require.False(t, p.playing)
+		require.Equal(t, 1, p.rewinds)
+
+		// the next beep after raising the volume is played
+		b.Stop()
+		b.VolumeUp()
+		require.False(t, p.playing)
+		b.Play()
+		require.True(t, p.playing)
+		require.Equal(t, 2, p.rewinds)
+	})
+
+	t.Run("raising the volume resumes the sounding beep", func(t *testing.T) {
+		p := &fakePlayer{volume: 0.5}
+		b := &Beep{p: p}
+
+		b.SetVolume(0)
+		b.Play()
+		require.False(t, p.playing)
+
+		b.VolumeUp()
+		require.True(t, p.playing, "the sound timer is still on")
+
+		b.Mute()
+		require.False(t, p.playing)
+		b.Unmute()
+		require.True(t, p.playing)
+
+		b.Stop()
+		b.VolumeUp()
+		require.False(t, p.playing, "the stopped beep stays stopped")
+	})
+
+	t.Run("volume down to zero", func(t *testing.T) {
+		p := &fakePlayer{volume: 0.4}
+		b := &Beep{p: p}
+
+		b.Play()
+		b.VolumeDown()
+		require.True(t, p.playing)
+
+		b.VolumeDown()
+		require.Equal(t, 0.0, p.volume)
+		require.False(t, p.playing)
+	})
+
+	t.Run("low volume is audible", func(t *testing.T) {
+		p := &fakePlayer{volume: 0.5}
+		b := &Beep{p: p}
+
+		b.Play()
+		b.SetVolume(0.04)
+		require.Equal(t, 0.04, p.volume)
+		require.True(t, p.playing)
+	})
+}
+
+func TestBeep_PlayStop(t *testing.T) {
+	t.Parallel()
+
+	p := &fakePlayer{volume: 0.5}
+	b := &Beep{p: p}
+
+	b.Play()
+	require.True(t, p.playing)
+
+	// the sounding beep isn't restarted
+	b.Play()
+	require.Equal(t, 1, p.rewinds)
+
+	b.Stop()
+	require.False(t, p.playing)
+}
+
+func TestBeep_Mute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("restores the volume", func(t *testing.T) {
+		p := &fakePlayer{}
+		b := &Beep{p: p}
+		b.SetVolume(0.6)
+		b.Play()
+
+		b.Mute()
+		require.True(t, b.IsMuted())
+		require.Equal(t, 0.0, p.volume)
+		require.False(t, p.playing)
+
+		// muting twice doesn't lose the volume
+		b.Mute()
+
+		b.Unmute()
+		require.False(t, b.IsMuted())
+		require.Equal(t, 0.6, p.volume)
+	})
+
+	t.Run("toggle", func(t *testing.T) {
+		p := &fakePlayer{volume: 0.4}
+		b := &Beep{p: p}
+
+		b.ToggleMute()
+		require.True(t, b.IsMuted())
+		b.ToggleMute()
+		require.False(t, b.IsMuted())
+		require.Equal(t, 0.4, p.volume)
+	})
+
+	t.Run("changing the volume unmutes", func(t *testing.T) {
+		p := &fakePlayer{volume: 0.6}
+		b := &Beep{p: p}
+
+		b.Mute()
+		b.VolumeUp()
+		require.False(t, b.IsMuted())
+		require.InDelta(t, 0.2, p.volume, 1e-9)
+	})
+}
+
+func TestBeep_SetFrequency(t *testing.T) {
+	t.Parallel()
+
+	newBeep := func() (*Beep, *fakePlayer) {
+		p := &fakePlayer{volume: 0.6}
+		b := &Beep{
+			p:  p,
+			hz: beepHz,
+			newPlayer: func(buf []byte) (player, error) {
+				return &fakePlayer{buf: buf}, nil
+			},
+		}
+		return b, p
+	}
+
+	t.Run("regenerates the tone", func(t *testing.T) {
+		b, old := newBeep()
+		b.Play()
+
+		require.NoError(t, b.SetFrequency(880))
+		require.Equal(t, 880.0, b.Frequency())
+
+		p := b.p.(*fakePlayer)
+		require.Equal(t, generateTone(Sine, 880, sampleRate), p.buf)
+		require.Equal(t, 0.6, p.volume)
+		require.True(t, p.playing, "the sounding beep goes on")
+		require.False(t, old.playing)
+		require.True(t, old.closed)
+	})
+
+	t.Run("invalid frequencies", func(t *testing.T) {
+		b, old := newBeep()
+
+		for _, hz := range []float64{0, -440, maxHz + 1} {
+			require.ErrorIs(t, b.SetFrequency(hz), ErrInvalidFrequency)
+		}
+		require.Same(t, old, b.p)
+		require.Equal(t, float64(beepHz), b.Frequency())
+	})
+}
@@ -0,0 +1,61 @@
+package beep
+
+import (
+	"fmt"
+	"math"
+)
+
+// Waveform is the shape of the beep tone.
+type Waveform int
+
+const (
+	Sine Waveform = iota
+	Square
+	Triangle
+	Sawtooth
+)
+
+func (w Waveform) String() string {
+	switch w {
+	case Sine:
+		return "sine"
+	case Square:
+		return "square"
+	case Triangle:
+		return "triangle"
+	case Sawtooth:
+		return "sawtooth"
+	}
+	return fmt.Sprintf("Waveform(%d)", int(w))
+}
+
+// sample returns the amplitude between -1 and 1 at the phase between 0 and 1 of a period.
+func (w Waveform) sample(phase float64) float64 {
+	switch w {
+	case Square:
+		if phase < 0.5 {
+			return 1
+		}
+		return -1
+	case Triangle:
+		if phase < 0.5 {
+			return 4*phase - 1
+		}
+		return 3 - 4*phase
+	case Sawtooth:
+		return 2*phase - 1
+	}
+	return math.Sin(2.0 * math.Pi * phase)
+}
+
+// generateTone returns 16-bit little-endian PCM samples of the tone in the waveform at the frequency.
+func generateTone(w Waveform, hz float64, numSamples int) []byte {
+	buf := make([]byte, numSamples*2)
+	for i := 0; i < numSamples; i++ {
+		_, phase := math.Modf(hz * float64(i) / float64(sampleRate))
+		s := int16(w.sample(phase) * math.MaxInt16)
+		buf[2*i] = byte(s)
+		buf[2*i+1] = byte(s >> 8)
+	}
+	return buf
+}
@@ -0,0 +1,63 @@
+package beep
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTone(t *testing.T) {
+	t.Parallel()
+
+	const numSamples = 1000
+	// the sample a quarter of the period in
+	quarter := sampleRate / beepHz / 4
+
+	type samples struct {
+		first, quarter int16
+	}
+	seen := make(map[samples]Waveform)
+
+	for _, w := range []Waveform{Sine, Square, Triangle, Sawtooth} {
+		t.Run(w.String(), func(t *testing.T) {
+			buf := generateTone(w, beepHz, numSamples)
+			require.Len(t, buf, numSamples*2)
+
+			s := samples{
+				first:   int16(binary.LittleEndian.Uint16(buf)),
+				quarter: int16(binary.LittleEndian.Uint16(buf[2*quarter:])),
+			}
+			other, ok := seen[s]
+			require.False(t, ok, "the same samples as %s", other)
+			seen[s] = w
+		})
+	}
+}
+
+func TestWaveform_String(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "square", Square.String())
+	require.Equal(t, "Waveform(9)", Waveform(9).String())
+}
+
+func TestGenerateTone_Frequency(t *testing.T) {
+	t.Parallel()
+
+	for _, hz := range []float64{220, 440, 1000} {
+		buf := generateTone(Sine, hz, sampleRate)
+
+		// a second of the sine crosses zero twice per period
+		crossings := 0
+		prev := int16(binary.LittleEndian.Uint16(buf))
+		for i := 2; i < len(buf); i += 2 {
+			s := int16(binary.LittleEndian.Uint16(buf[i:]))
+			if (prev < 0) != (s < 0) {
+				crossings++
+			}
+			prev = s
+		}
+		require.InDelta(t, 2*hz, crossings, 1, "%v Hz", hz)
+	}
+}
@@ -0,0 +1,137 @@
+package beep
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	wavHeaderSize    = 44
+	wavChannels      = 1
+	wavBitsPerSample = 16
+	wavBlockAlign    = wavChannels * wavBitsPerSample / 8
+
+	// of the emulated time the recording follows
+	framesPerSecond = 60
+)
+
+// WAVRecorder is a sound player that writes beeps to a WAV file instead of speakers.
+// The time between beeps is filled with silence, so the recording follows the gameplay.
+// The time is the one of the emulated frames, so the recording matches the frames of the game
+// however fast they were run.
+type WAVRecorder struct {
+	w        io.Writer
+	frames   func() uint64
+	waveform Waveform
+	hz       float64
+
+	// 16-bit mono PCM samples
+	samples []byte
+
+	// the frames of the machine at the last look and the frames recorded up to it
+	lastFrames uint64
+	recorded   uint64
+
+	// the recorded frame the sounding beep started at
+	playing      bool
+	playingSince uint64
+}
+
+// NewWAVRecorder returns a recorder of the beeps of the machine the frames are counted by,
+// e.g. the FrameCount of the machine at 60 frames per second.
+func NewWAVRecorder(w io.Writer, frames func() uint64) *WAVRecorder {
+	return &WAVRecorder{
+		w:      w,
+		frames: frames,
+		hz:     beepHz,
+	}
+}
+
+// SetWaveform sets the waveform of the beeps recorded after it, sine is the default.
+func (r *WAVRecorder) SetWaveform(w Waveform) {
+	r.waveform = w
+}
+
+// SetFrequency sets the pitch of the beeps recorded after it in Hz, the limits are the ones of Beep.SetFrequency.
+func (r *WAVRecorder) SetFrequency(hz float64) error {
+	if err := validateFrequency(hz); err != nil {
+		return err
+	}
+	r.hz = hz
+	return nil
+}
+
+// Play starts a beep at the current moment of the recording, it sounds until Stop.
+func (r *WAVRecorder) Play() {
+	if r.playing {
+		return
+	}
+	r.playing = true
+	r.playingSince = r.frame()
+}
+
+// Stop ends the sounding beep and appends it to the recording.
+func (r *WAVRecorder) Stop() {
+	if !r.playing {
+		return
+	}
+	r.playing = false
+
+	from := r.samplePos(r.playingSince)
+	if from > len(r.samples) {
+		r.samples = append(r.samples, make([]byte, from-len(r.samples))...)
+	}
+
+	tone := generateTone(r.waveform, r.hz, (r.samplePos(r.frame())-from)/wavBlockAlign)
+	r.samples = append(r.samples[:from], tone...)
+}
+
+// frame returns the number of frames recorded so far. The frames the machine turns back,
+// e.g. on a rewind or a reset, don't turn the recording back.
+func (r *WAVRecorder) frame() uint64 {
+	frames := r.frames()
+	if frames > r.lastFrames {
+		r.recorded += frames - r.lastFrames
+	}
+	r.lastFrames = frames
+	return r.recorded
+}
+
+// samplePos returns the offset of the sample the frame starts at in the recording
+func (r *WAVRecorder) samplePos(frame uint64) int {
+	return int(frame*sampleRate/framesPerSecond) * wavBlockAlign
+}
+
+// NumSamples returns the number of recorded samples.
+func (r *WAVRecorder) NumSamples() int {
+	return len(r.samples) / wavBlockAlign
+}
+
+// Close ends the sounding beep and writes the WAV header and the recorded samples.
+func (r *WAVRecorder) Close() error {
+	r.Stop()
+
+	header := make([]byte, wavHeaderSize)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(wavHeaderSize-8+len(r.samples)))
+	copy(header[8:], "WAVE")
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16) // fmt chunk size
+	binary.LittleEndian.PutUint16(header[20:], 1)  // PCM
+	binary.LittleEndian.PutUint16(header[22:], wavChannels)
+	binary.LittleEndian.PutUint32(header[24:], sampleRate)
+	binary.LittleEndian.PutUint32(header[28:], sampleRate*wavBlockAlign)
+	binary.LittleEndian.PutUint16(header[32:], wavBlockAlign)
+	binary.LittleEndian.PutUint16(header[34:], wavBitsPerSample)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(len(r.samples)))
+
+	if _, err := r.w.Write(header); err != nil {
+		return fmt.Errorf("couldn't write a wav header: %w", err)
+	}
+	if _, err := r.w.Write(r.samples); err != nil {
+		return fmt.Errorf("couldn't write wav samples: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package beep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAVRecorder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("short beep", func(t *testing.T) {
+		var frames uint64
+
+		var buf bytes.Buffer
+		recorder := NewWAVRecorder(&buf, func() uint64 { return frames })
+
+		// the beep starts after a half second of silence and lasts a quarter second
+		frames += 30
+		recorder.Play()
+		frames += 15
+		recorder.Stop()
+
+		expectedSamples := sampleRate/2 + sampleRate/4
+		require.Equal(t, expectedSamples, recorder.NumSamples())
+
+		// the silence after the beep isn't recorded
+		frames += 60
+		require.NoError(t, recorder.Close())
+
+		data := buf.Bytes()
+		require.Len(t, data, wavHeaderSize+expectedSamples*wavBlockAlign)
+		require.Equal(t, "RIFF", string(data[0:4]))
+		require.Equal(t, uint32(len(data)-8), binary.LittleEndian.Uint32(data[4:]))
+		require.Equal(t, "WAVE", string(data[8:12]))
+		require.Equal(t, "fmt ", string(data[12:16]))
+		require.Equal(t, uint16(1), binary.LittleEndian.Uint16(data[20:]), "PCM")
+		require.Equal(t, uint16(wavChannels), binary.LittleEndian.Uint16(data[22:]), "channels")
+		require.Equal(t, uint32(sampleRate), binary.LittleEndian.Uint32(data[24:]), "sample rate")
+		require.Equal(t, uint16(wavBitsPerSample), binary.LittleEndian.Uint16(data[34:]), "bits per sample")
+		require.Equal(t, "data", string(data[36:40]))
+		require.Equal(t, uint32(expectedSamples*wavBlockAlign), binary.LittleEndian.Uint32(data[40:]))
+
+		// silence before the beep
+		require.Equal(t, make([]byte, sampleRate/2*wavBlockAlign), data[wavHeaderSize:wavHeaderSize+sampleRate/2*wavBlockAlign])
+	})
+
+	t.Run("play while sounding", func(t *testing.T) {
+		var frames uint64
+		recorder := NewWAVRecorder(&bytes.Buffer{}, func() uint64 { return frames })
+
+		recorder.Play()
+		frames += 6
+		recorder.Play()
+		frames += 6
+		recorder.Stop()
+
+		require.Equal(t, sampleRate/5, recorder.NumSamples())
+	})
+
+	t.Run("close while sounding", func(t *testing.T) {
+		var frames uint64
+		recorder := NewWAVRecorder(&bytes.Buffer{}, func() uint64 { return frames })
+
+		recorder.Play()
+		frames += 6
+		require.NoError(t, recorder.Close())
+
+		require.Equal(t, sampleRate/10, recorder.NumSamples())
+	})
+
+	t.Run("the frames turned back", func(t *testing.T) {
+		var frames uint64
+		recorder := NewWAVRecorder(&bytes.Buffer{}, func() uint64 { return frames })
+
+		frames = 60
+		recorder.Play()
+		frames = 30 // e.g. a rewind
+		recorder.Stop()
+		require.Equal(t, sampleRate, recorder.NumSamples(), "the beep has no length")
+
+		// the recording goes on from where it was
+		recorder.Play()
+		frames += 6
+		recorder.Stop()
+		require.Equal(t, sampleRate+sampleRate/10, recorder.NumSamples())
+	})
+}
@@ -0,0 +1,293 @@
+package chip8
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownMnemonic = errors.New("unknown mnemonic")
+
+// Assemble returns the rom bytes of the source in the mnemonics of DisassembleOpcode, e.g.
+//
+//	start:
+//		LD V0, 0x11 ; a comment
+//		DRW V0, V1, 5
+//		JP start
+//	sprite:
+//		DB 0xF0
+//
+// There is an instruction or a directive per line. DB emits a byte and DW a big-endian word.
+// A label is a name followed by a colon, it's the address of the next byte and can be used
+// for any address operand. Labels are resolved in a second pass, so they can be used before they are defined.
+// Numbers are decimal or prefixed with 0x, 0o, or 0b. Mnemonics and registers are case-insensitive.
+func Assemble(src string) ([]byte, error) {
+	lines := strings.Split(src, "\n")
+
+	// the first pass finds the addresses of the labels
+	labels := make(map[string]uint16)
+	addr := uint16(entryPoint)
+	for i, line := range lines {
+		label, mnemonic, _ := splitAsmLine(line)
+		if len(label) > 0 {
+			if _, ok := labels[label]; ok {
+				return nil, fmt.Errorf("line %d: label %s is already defined", i+1, label)
+			}
+			labels[label] = addr
+		}
+		switch {
+		case len(mnemonic) == 0:
+		case mnemonic == "DB":
+			addr++
+		default:
+			addr += 2
+		}
+	}
+
+	// the second pass encodes the instructions
+	a := assembler{labels: labels}
+	var out []byte
+	for i, line := range lines {
+		_, mnemonic, operands := splitAsmLine(line)
+		if len(mnemonic) == 0 {
+			continue
+		}
+
+		if mnemonic == "DB" {
+			b, err := a.directiveByte(operands)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			out = append(out, b)
+			continue
+		}
+
+		opcode, err := a.encode(mnemonic, operands)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		out = append(out, byte(opcode>>8), byte(opcode))
+	}
+
+	return out, nil
+}
+
+// splitAsmLine splits a source line into the label, the upper-cased mnemonic, and the operands.
+// The parts that aren't on the line are empty.
+func splitAsmLine(line string) (label string, mnemonic string, operands []string) {
+	line, _, _ = strings.Cut(line, ";")
+	line = strings.TrimSpace(line)
+
+	if name, rest, ok := strings.Cut(line, ":"); ok && isAsmLabel(strings.TrimSpace(name)) {
+		label = strings.TrimSpace(name)
+		line = strings.TrimSpace(rest)
+	}
+	if len(line) == 0 {
+		return label, "", nil
+	}
+
+	mnemonic, rest, _ := strings.Cut(line, " ")
+	mnemonic = strings.ToUpper(strings.TrimSpace(mnemonic))
+	if rest = strings.TrimSpace(rest); len(rest) > 0 {
+		for _, operand := range strings.Split(rest, ",") {
+			operands = append(operands, strings.TrimSpace(operand))
+		}
+	}
+	return label, mnemonic, operands
+}
+
+func isAsmLabel(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i, r := range s {
+		isLetter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && (i == 0 || !isDigit) {
+			return false
+		}
+	}
+	return true
+}
+
+// the operands that are neither registers nor values
+var asmKeywords = map[string]bool{"I": true, "[I]": true, "DT": true, "ST": true, "K": true, "F": true, "B": true, "R": true}
+
+// asmMnemonics are the mnemonics encode knows
+var asmMnemonics = map[string]bool{
+	"CLS": true, "RET": true, "SCR": true, "SCL": true, "EXIT": true, "LOW": true, "HIGH": true, "SCD": true, "SYS": true,
+	"JP": true, "CALL": true, "SE": true, "SNE": true, "LD": true, "ADD": true, "OR": true, "AND": true, "XOR": true,
+	"SUB": true, "SHR": true, "SUBN": true, "SHL": true, "RND": true, "DRW": true, "SKP": true, "SKNP": true, "DW": true,
+}
+
+type assembler struct {
+	labels map[string]uint16
+}
+
+// encode returns the opcode of the instruction.
+func (a assembler) encode(mnemonic string, operands []string) (uint16, error) {
+	if !asmMnemonics[mnemonic] {
+		return 0, fmt.Errorf("%w %s", ErrUnknownMnemonic, mnemonic)
+	}
+
+	// the operands are matched by their kinds, e.g. "LD V,N" is LD Vx, byte
+	kinds := make([]string, len(operands))
+	for i, operand := range operands {
+		switch upper := strings.ToUpper(operand); {
+		case asmKeywords[upper]:
+			kinds[i] = upper
+		case isAsmRegister(upper):
+			kinds[i] = "V"
+		default:
+			kinds[i] = "N"
+		}
+	}
+	signature := strings.TrimSpace(mnemonic + " " + strings.Join(kinds, ","))
+
+	// the values of the operands, in order
+	var err error
+	reg := func(i int) uint16 {
+		return uint16(strings.IndexByte("0123456789ABCDEF", strings.ToUpper(operands[i])[1]))
+	}
+	value := func(i int, max uint16) uint16 {
+		v, verr := a.value(operands[i], max)
+		if verr != nil && err == nil {
+			err = verr
+		}
+		return v
+	}
+
+	var opcode uint16
+	switch signature {
+	case "CLS":
+		opcode = 0x00e0
+	case "RET":
+		opcode = 0x00ee
+	case "SCR":
+		opcode = 0x00fb
+	case "SCL":
+		opcode = 0x00fc
+	case "EXIT":
+		opcode = 0x00fd
+	case "LOW":
+		opcode = 0x00fe
+	case "HIGH":
+		opcode = 0x00ff
+	case "SCD N":
+		opcode = 0x00c0 | value(0, 0xf)
+	case "SYS N":
+		opcode = value(0, 0xfff)
+	case "JP N":
+		opcode = 0x1000 | value(0, 0xfff)
+	case "JP V,N":
+		if reg(0) != 0 {
+			return 0, fmt.Errorf("JP with an offset takes V0, got %s", operands[0])
+		}
+		opcode = 0xb000 | value(1, 0xfff)
+	case "CALL N":
+		opcode = 0x2000 | value(0, 0xfff)
+	case "SE V,N":
+		opcode = 0x3000 | reg(0)<<8 | value(1, 0xff)
+	case "SNE V,N":
+		opcode = 0x4000 | reg(0)<<8 | value(1, 0xff)
+	case "SE V,V":
+		opcode = 0x5000 | reg(0)<<8 | reg(1)<<4
+	case "LD V,N":
+		opcode = 0x6000 | reg(0)<<8 | value(1, 0xff)
+	case "ADD V,N":
+		opcode = 0x7000 | reg(0)<<8 | value(1, 0xff)
+	case "LD V,V":
+		opcode = 0x8000 | reg(0)<<8 | reg(1)<<4
+	case "OR V,V":
+		opcode = 0x8001 | reg(0)<<8 | reg(1)<<4
+	case "AND V,V":
+		opcode = 0x8002 | reg(0)<<8 | reg(1)<<4
+	case "XOR V,V":
+		opcode = 0x8003 | reg(0)<<8 | reg(1)<<4
+	case "ADD V,V":
+		opcode = 0x8004 | reg(0)<<8 | reg(1)<<4
+	case "SUB V,V":
+		opcode = 0x8005 | reg(0)<<8 | reg(1)<<4
+	case "SHR V,V":
+		opcode = 0x8006 | reg(0)<<8 | reg(1)<<4
+	case "SUBN V,V":
+		opcode = 0x8007 | reg(0)<<8 | reg(1)<<4
+	case "SHL V,V":
+		opcode = 0x800e | reg(0)<<8 | reg(1)<<4
+	case "SNE V,V":
+		opcode = 0x9000 | reg(0)<<8 | reg(1)<<4
+	case "LD I,N":
+		opcode = 0xa000 | value(1, 0xfff)
+	case "RND V,N":
+		opcode = 0xc000 | reg(0)<<8 | value(1, 0xff)
+	case "DRW V,V,N":
+		opcode = 0xd000 | reg(0)<<8 | reg(1)<<4 | value(2, 0xf)
+	case "SKP V":
+		opcode = 0xe09e | reg(0)<<8
+	case "SKNP V":
+		opcode = 0xe0a1 | reg(0)<<8
+	case "LD V,DT":
+		opcode = 0xf007 | reg(0)<<8
+	case "LD V,K":
+		opcode = 0xf00a | reg(0)<<8
+	case "LD DT,V":
+		opcode = 0xf015 | reg(1)<<8
+	case "LD ST,V":
+		opcode = 0xf018 | reg(1)<<8
+	case "ADD I,V":
+		opcode = 0xf01e | reg(1)<<8
+	case "LD F,V":
+		opcode = 0xf029 | reg(1)<<8
+	case "LD B,V":
+		opcode = 0xf033 | reg(1)<<8
+	case "LD [I],V":
+		opcode = 0xf055 | reg(1)<<8
+	case "LD V,[I]":
+		opcode = 0xf065 | reg(0)<<8
+	case "LD R,V":
+		opcode = 0xf075 | reg(1)<<8
+	case "LD V,R":
+		opcode = 0xf085 | reg(0)<<8
+	case "DW N":
+		opcode = value(0, 0xffff)
+	default:
+		return 0, fmt.Errorf("invalid operands of %s: %s", mnemonic, strings.Join(operands, ", "))
+	}
+	return opcode, err
+}
+
+// directiveByte returns the byte of a DB directive.
+func (a assembler) directiveByte(operands []string) (byte, error) {
+	if len(operands) != 1 {
+		return 0, fmt.Errorf("DB takes a single byte, got %d operands", len(operands))
+	}
+	v, err := a.value(operands[0], 0xff)
+	return byte(v), err
+}
+
+// value returns the number or the address of the label, it must be at most max.
+func (a assembler) value(operand string, max uint16) (uint16, error) {
+	var v uint64
+	if addr, ok := a.labels[operand]; ok {
+		v = uint64(addr)
+	} else {
+		var err error
+		v, err = strconv.ParseUint(operand, 0, 16)
+		if err != nil {
+			if isAsmLabel(operand) {
+				return 0, fmt.Errorf("label %s isn't defined", operand)
+			}
+			return 0, fmt.Errorf("invalid number %s", operand)
+		}
+	}
+
+	if v > uint64(max) {
+		return 0, fmt.Errorf("%s is out of the range 0..0x%X", operand, max)
+	}
+	return uint16(v), nil
+}
+
+func isAsmRegister(s string) bool {
+	return len(s) == 2 && s[0] == 'V' && strings.IndexByte("0123456789ABCDEF", s[1]) >= 0
+}
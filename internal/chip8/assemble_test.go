@@ -0,0 +1,89 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssemble(t *testing.T) {
+	t.Parallel()
+
+	t.Run("program", func(t *testing.T) {
+		src := `
+			LD V0, 0x11 ; v[0] = 0x11
+			ld v1, 2
+			LD I, sprite
+		loop:
+			DRW V0, V1, 5
+			ADD V0, 0b1
+			SE V0, 0x20
+			JP loop
+		end: JP end
+		sprite:
+			DB 0xF0
+			DB 0x90
+		`
+
+		data, err := Assemble(src)
+		require.NoError(t, err)
+		require.Equal(t, []byte{
+			0x60, 0x11, // 0x200: v[0] = 0x11
+			0x61, 0x02, // 0x202: v[1] = 2
+			0xa2, 0x10, // 0x204: vI = 0x210
+			0xd0, 0x15, // 0x206: draw(v[0], v[1], 5)
+			0x70, 0x01, // 0x208: v[0] += 1
+			0x30, 0x20, // 0x20A: skip if v[0] == 0x20
+			0x12, 0x06, // 0x20C: jump to 0x206
+			0x12, 0x0e, // 0x20E: jump to 0x20E
+			0xf0, 0x90, // 0x210: sprite
+		}, data)
+	})
+
+	t.Run("call a label defined later", func(t *testing.T) {
+		data, err := Assemble("CALL sub\nEXIT\nsub: RET")
+		require.NoError(t, err)
+		require.Equal(t, []byte{
+			0x22, 0x04, // 0x200: call 0x204
+			0x00, 0xfd, // 0x202: exit
+			0x00, 0xee, // 0x204: return
+		}, data)
+	})
+
+	t.Run("round trip of the disassembly", func(t *testing.T) {
+		opcodes := []uint16{
+			0x00e0, 0x00ee, 0x00c3, 0x00fb, 0x00fc, 0x00fd, 0x00fe, 0x00ff, 0x0123,
+			0x1234, 0x2345, 0x3a11, 0x4b22, 0x5120, 0x6a11, 0x7b22,
+			0x8120, 0x8121, 0x8122, 0x8123, 0x8124, 0x8125, 0x8126, 0x8127, 0x812e,
+			0x9120, 0xa123, 0xb456, 0xc1ff, 0xd125, 0xe19e, 0xe1a1,
+			0xf107, 0xf10a, 0xf115, 0xf118, 0xf11e, 0xf129, 0xf133, 0xf155, 0xf165, 0xf175, 0xf185,
+			0x5121, 0xffff,
+		}
+		for _, opcode := range opcodes {
+			mnemonic := DisassembleOpcode(opcode)
+			data, err := Assemble(mnemonic)
+			require.NoError(t, err, mnemonic)
+			require.Equal(t, []byte{byte(opcode >> 8), byte(opcode)}, data, mnemonic)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Assemble("CLS\nMOV V0, V1")
+		require.ErrorIs(t, err, ErrUnknownMnemonic)
+		require.ErrorContains(t, err, "line 2")
+
+		tests := map[string]string{
+			"undefined label":   "JP nowhere",
+			"duplicated label":  "a: CLS\na: CLS",
+			"out of range":      "LD V0, 0x100",
+			"invalid operands":  "LD V0",
+			"invalid register":  "DRW V0, VG, 5",
+			"offset isn't V0":   "JP V1, 0x200",
+			"several DB values": "DB 1, 2",
+		}
+		for name, src := range tests {
+			_, err := Assemble(src)
+			require.Error(t, err, name)
+		}
+	})
+}
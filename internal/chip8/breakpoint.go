@@ -0,0 +1,32 @@
+package chip8
+
+// AddBreakpoint pauses the machine before the instruction at addr is executed by Emulate.
+// Resuming the machine executes the instruction and runs on, so does Step.
+func (c *Chip8) AddBreakpoint(addr uint16) {
+	if c.breakpoints == nil {
+		c.breakpoints = make(map[uint16]bool)
+	}
+	c.breakpoints[addr] = true
+}
+
+// RemoveBreakpoint removes the breakpoint at addr, if any.
+func (c *Chip8) RemoveBreakpoint(addr uint16) {
+	delete(c.breakpoints, addr)
+}
+
+// hitBreakpoint reports whether the next instruction is at a breakpoint the machine hasn't paused at yet.
+func (c *Chip8) hitBreakpoint() bool {
+	if c.atBreakpoint && c.pc == c.breakpointPC {
+		// the machine was resumed at the breakpoint
+		c.atBreakpoint = false
+		return false
+	}
+	c.atBreakpoint = false
+
+	if !c.breakpoints[c.pc] {
+		return false
+	}
+	c.atBreakpoint = true
+	c.breakpointPC = c.pc
+	return true
+}
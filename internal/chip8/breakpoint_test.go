@@ -0,0 +1,76 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_Breakpoint(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x01, // 0x200: v[0] = 1
+			0x61, 0x02, // 0x202: v[1] = 2
+			0x62, 0x03, // 0x204: v[2] = 3
+			0x12, 0x00, // 0x206: jump to 0x200
+		},
+	}
+
+	t.Run("pauses before the instruction", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.SetTPS(maxTPS)
+		chip8.LoadRom(rom)
+		chip8.AddBreakpoint(0x202)
+
+		for i := 0; i < 3; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, StatePaused, chip8.GetState())
+		require.Equal(t, uint16(0x202), chip8.pc, "the instruction isn't executed")
+		require.Equal(t, uint8(0), chip8.regsV[1])
+
+		// step past it
+		chip8.Step()
+		require.Equal(t, uint16(0x204), chip8.pc)
+		require.Equal(t, uint8(2), chip8.regsV[1])
+	})
+
+	t.Run("resumes at the breakpoint", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.SetTPS(maxTPS)
+		chip8.LoadRom(rom)
+		chip8.AddBreakpoint(0x202)
+
+		chip8.Emulate()
+		chip8.Emulate()
+		require.Equal(t, StatePaused, chip8.GetState())
+
+		chip8.TogglePause()
+		chip8.Emulate()
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.Equal(t, uint16(0x204), chip8.pc)
+
+		// hit again after the loop
+		for i := 0; i < 4; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, StatePaused, chip8.GetState())
+		require.Equal(t, uint16(0x202), chip8.pc)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.SetTPS(maxTPS)
+		chip8.LoadRom(rom)
+		chip8.AddBreakpoint(0x202)
+		chip8.RemoveBreakpoint(0x202)
+
+		for i := 0; i < 3; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.Equal(t, uint16(0x206), chip8.pc)
+	})
+}
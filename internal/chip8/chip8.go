@@ -1,14 +1,12 @@
 package chip8
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	v2 "math/rand/v2"
-	"os"
-	"time"
-
-	"github.com/nevisdale/go-chip8/internal/beep"
 )
 
 const (
@@ -26,16 +24,38 @@ const (
 	screenHeight = 32
 	screenSize   = screenWidth * screenHeight
 
+	// SUPER-CHIP high resolution mode doubles both sides of the display
+	hiresScreenWidth  = 128
+	hiresScreenHeight = 64
+	// the screen buffer fits the largest resolution
+	screenBufferSize = hiresScreenWidth * hiresScreenHeight
+
 	// // http://devernay.free.fr/hacks/chip8/C8TECH10.HTM#2.3
 	keyPadSize = 0x10
 
 	// Ticks per second
 	// see more http://devernay.free.fr/hacks/chip8/C8TECH10.HTM#2.5
 	defaultTPS = 60
+	minTPS     = 1
+	maxTPS     = 2000
+)
 
-	stackMaxSize = 16
+var (
+	ErrInvalidKey     = errors.New("key is out of the keypad range")
+	ErrRomTooLarge    = errors.New("rom doesn't fit in ram")
+	ErrStackOverflow  = errors.New("call with the full stack")
+	ErrStackUnderflow = errors.New("return with the empty stack")
+	ErrPCOutOfRAM     = errors.New("program counter is out of ram")
+
+	ErrInvalidCyclesPerFrame = errors.New("cycles per frame are out of range")
 )
 
+// FramesPerSecond is the display and timers rate of the original hardware
+const FramesPerSecond = 60
+
+// MaxCyclesPerFrame is the most instructions per frame, the ones of the max tps
+const MaxCyclesPerFrame = maxTPS / FramesPerSecond
+
 // http://devernay.free.fr/hacks/chip8/C8TECH10.HTM#font
 var font []byte = []byte{
 	0xF0, 0x90, 0x90, 0x90, 0xF0, // 0
@@ -56,6 +76,26 @@ var font []byte = []byte{
 	0xF0, 0x80, 0xF0, 0x80, 0x80, // F
 }
 
+// SoundPlayer sounds the beep while the sound timer is active.
+type SoundPlayer interface {
+	// Play starts the beep when the sound timer is set
+	Play()
+	// Stop ends the beep when the sound timer reaches zero
+	Stop()
+}
+
+// volumeController is implemented by sound players with an adjustable volume.
+type volumeController interface {
+	VolumeUp()
+	VolumeDown()
+}
+
+// muteController is implemented by sound players that can be muted.
+type muteController interface {
+	ToggleMute()
+	IsMuted() bool
+}
+
 type State int
 
 func (s State) String() string {
@@ -64,24 +104,46 @@ func (s State) String() string {
 		return "Running"
 	case StatePaused:
 		return "Paused"
+	case StateHalted:
+		return "Halted"
+	case StateQuit:
+		return "Quit"
 	}
-	return ""
+	return fmt.Sprintf("State(%d)", int(s))
 }
 
 const (
 	StateRunning State = iota
 	StatePaused
+	// the machine hit an error and can't continue, see Err
+	StateHalted
+	// the rom exited with 00FD (SUPER-CHIP)
+	StateQuit
 )
 
 type Chip8 struct {
 	ram [ramSizeBytes]byte
 	rom Rom
+	// the free ram is filled with random bytes of the seed instead of zeros
+	randomizeRAM bool
+	ramSeed      uint64
 
 	state State
 
-	screen [screenSize]bool
+	// pixels row by row of the current resolution
+	screen [screenBufferSize]bool
+	// SUPER-CHIP 128x64 mode, see 00FF
+	hires bool
+	// the screen at the last frame boundary, see EndFrame
+	frame      [screenBufferSize]bool
+	frameHires bool
+	frameEnded bool
 
 	keyPad [keyPadSize]bool
+	// sequence number of the last press of every key, the greater is the more recent
+	keyPressOrder [keyPadSize]uint64
+	keyPresses    uint64
+	keyWaitPolicy KeyWaitPolicy
 
 	// 16 general purpose 8-bit registers
 	regsV [0x10]uint8
@@ -94,10 +156,10 @@ type Chip8 struct {
 	// Used to store the currently executing address.
 	pc uint16
 
-	// The stack is an array of 16 16-bit values,
+	// The stack is an array of 16-bit values,
 	// used to store the address that the interpreter shoud return to when finished with a subroutine.
-	// Chip-8 allows for up to 16 levels of nested subroutines
-	stack [stackMaxSize]uint16
+	// Its length is the levels of nested subroutines, 16 by default, see SetStackSize
+	stack []uint16
 
 	// used to point to the next level of the stack.
 	// starts from 0
@@ -106,12 +168,93 @@ type Chip8 struct {
 	delayTimer uint8
 	soundTimer uint8
 
+	// frames since the start
+	frameCount uint64
+	// instructions executed since the start
+	instructionCount uint64
+	// FramesPerSecond is added for every instruction, a frame ends every tps
+	frameTime int
+	// DXYN was executed in the current frame, see the DisplayWait quirk
+	drewThisFrame bool
+
+	// SCHIP flag registers, see FX75/FX85
+	flags       [flagRegistersSize]uint8
+	flagStorage FlagStorage
+	// FX75 changed the flags since they were saved
+	flagsDirty bool
+
+	quirks Quirks
+
+	// the font sprites copied to low memory, see SetFont. nil is the default font
+	customFont []byte
+
+	// FX0A is blocked until a key is pressed and released
+	waitingForKey bool
+	// the key FX0A waits to be released. valid if keyWaitPressed
+	keyWaitKey     uint8
+	keyWaitPressed bool
+
+	overrunPolicy MemoryOverrunPolicy
+	// unknown opcodes halt the machine instead of being skipped, see SetStrictMode
+	strict bool
+	// WriteMemory can change the memory below the entry point
+	allowReservedWrites bool
+	// the error that halted the machine
+	err error
+
 	// ticks per second
 	tps int
-	// time that takes to make a one command (tick)
-	tickDuration time.Duration
-
-	beepPlayer *beep.Beep
+	// paces Run
+	clock Clock
+	// instructions per second on top of tps. 0 if not limited
+	maxIPS int
+
+	soundPlayer SoundPlayer
+
+	// source of CXNN random numbers. nil means the global one
+	rand *v2.Rand
+
+	// recent states to rewind gameplay. nil if rewinding is disabled
+	rewind *rewindBuffer
+	// the state at the start of the current frame is in the rewind buffer
+	rewindSaved bool
+
+	// states before the instructions executed by Step and the undone ones to replay
+	stepHistory *rewindBuffer
+	stepRedo    []Snapshot
+
+	// addresses Emulate pauses at, see AddBreakpoint
+	breakpoints map[uint16]bool
+	// the machine is paused at the breakpoint at breakpointPC and runs from it on resume
+	atBreakpoint bool
+	breakpointPC uint16
+
+	// sorted addresses and the V registers Emulate pauses after a change of, see AddMemoryWatch
+	memoryWatches   []uint16
+	registerWatches [0x10]bool
+	// the values of the watched memory before the instruction, reused between instructions
+	watchBuf []byte
+	// the watch that paused the machine last, valid if watchHitOK
+	watchHit   WatchHit
+	watchHitOK bool
+
+	// the last executed instructions for crash reports
+	recent recentInstructions
+
+	// executed instructions are traced here. nil if tracing is disabled
+	trace io.Writer
+	// executed instructions are passed here. nil if not set
+	traceFunc func(TraceEvent)
+	// mnemonics of the traced instructions. nil traces all of them
+	traceFilter map[string]bool
+
+	// called when the rom jumps to itself, see SetIdleFunc. nil if not set
+	idleFunc func(pc uint16)
+	// the idle func is called for the current self jump
+	idleReported bool
+
+	// suspicious but legal rom behavior is reported here. nil if diagnostics are disabled
+	diagnostics io.Writer
 }
 
 func NewChip8() Chip8 {
@@ -119,8 +262,11 @@ func NewChip8() Chip8 {
 		state: StateRunning,
 		pc:    entryPoint,
 
-		tps:          defaultTPS,
-		tickDuration: time.Second / time.Duration(defaultTPS),
+		tps: defaultTPS,
+
+		stack: make([]uint16, defaultStackSize),
+
+		clock: systemClock{},
 	}
 
 	copy(chip8.ram[:], font)
@@ -128,47 +274,131 @@ func NewChip8() Chip8 {
 	return chip8
 }
 
-func (c *Chip8) LoadRom(rom Rom) {
+// LoadRom copies the rom to ram at the entry point and starts it from the initial state, see Reset.
+// The leftovers of the previous rom, e.g. its registers or screen, are cleared.
+// A rom that doesn't fit from the entry point is rejected with ErrRomTooLarge and nothing is loaded.
+func (c *Chip8) LoadRom(rom Rom) error {
+	if len(rom.Data) > romMaxSizeBytes {
+		return fmt.Errorf("load rom %s of %d bytes, max size is %d bytes: %w",
+			rom.Name, len(rom.Data), romMaxSizeBytes, ErrRomTooLarge,
+		)
+	}
+
+	// the flags of the previous run are kept before the game restarts
+	if err := c.SaveFlags(); err != nil {
+		log.Println(err.Error())
+	}
+
 	c.rom = rom
-	copy(c.ram[c.pc:], rom.Data)
+	c.Reset()
+	return nil
+}
+
+func (c Chip8) GetRom() Rom {
+	return c.rom
 }
 
 func (c Chip8) GetRomName() string {
 	return c.rom.Name
 }
 
-func (c *Chip8) SetSoundPlayer(beep *beep.Beep) {
-	c.beepPlayer = beep
+func (c *Chip8) SetSoundPlayer(player SoundPlayer) {
+	c.soundPlayer = player
+}
+
+// SetDiagnosticWriter enables reporting of suspicious rom behavior, e.g. drawing sprites
+// from the interpreter-reserved memory. nil disables diagnostics.
+func (c *Chip8) SetDiagnosticWriter(w io.Writer) {
+	c.diagnostics = w
 }
 
+// SetTPS sets how many instructions run per second, clamped to 1..2000. See InstructionRate.
 func (c *Chip8) SetTPS(tps int) {
-	if tps > 0 {
-		c.tps = tps
-		c.tickDuration = time.Second / time.Duration(tps)
-	}
+	tps = min(max(tps, minTPS), maxTPS)
+	c.tps = tps
 }
 
 func (c Chip8) GetTPS() int {
 	return c.tps
 }
 
-func (c *Chip8) Emulate() {
-	start := time.Now()
-	defer func() {
-		elapsed := time.Since(start)
-		needToSleep := c.tickDuration - elapsed
-		time.Sleep(needToSleep)
-	}()
+// SetCyclesPerFrame sets how many instructions run per 60 Hz frame, e.g. 8-20 for most games.
+// It's the tps of n frames, the timers still tick once per frame.
+// n must be between 1 and MaxCyclesPerFrame, the max tps, otherwise ErrInvalidCyclesPerFrame is returned
+// and the tps isn't changed.
+func (c *Chip8) SetCyclesPerFrame(n int) error {
+	if n < 1 || n > MaxCyclesPerFrame {
+		return fmt.Errorf("%d cycles per frame, max is %d: %w", n, MaxCyclesPerFrame, ErrInvalidCyclesPerFrame)
+	}
+	c.SetTPS(n * FramesPerSecond)
+	return nil
+}
+
+// CyclesPerFrame returns how many instructions run per 60 Hz frame, at least 1.
+func (c Chip8) CyclesPerFrame() int {
+	return c.instructionsPerFrame()
+}
+
+// DelayTimer returns the current value of the delay timer.
+func (c Chip8) DelayTimer() uint8 {
+	return c.delayTimer
+}
+
+// SoundTimer returns the current value of the sound timer. The beep sounds while it's above zero.
+func (c Chip8) SoundTimer() uint8 {
+	return c.soundTimer
+}
 
+// Emulate executes the next instruction if the machine is running.
+// It doesn't block: the caller runs it at the InstructionRate, e.g. the renderer or Run.
+// It returns the error that halted the machine, see Err.
+func (c *Chip8) Emulate() error {
 	if c.state != StateRunning {
-		return
+		return c.err
 	}
 
-	if c.pc >= ramSizeBytes {
+	if c.hitBreakpoint() {
+		c.state = StatePaused
+		return c.err
+	}
+
+	// the step history is valid only while the machine is stepped manually
+	c.clearStepHistory()
+
+	pc := c.pc
+	before, watched := c.recordWatches()
+	c.step()
+	if watched && c.state == StateRunning && c.hitWatch(before) {
+		c.state = StatePaused
+	}
+	c.reportIdle(pc)
+	return c.err
+}
+
+// step executes a single instruction
+func (c *Chip8) step() {
+	if c.stopped() {
 		return
 	}
+	// both bytes of the opcode must be in ram, e.g. a jump to FFF or a skip at FFE runs past the end
+	if int(c.pc)+1 >= ramSizeBytes {
+		c.halt(fmt.Errorf("%04X: %w", c.pc, ErrPCOutOfRAM))
+		return
+	}
+
+	if c.rewind != nil && !c.rewindSaved {
+		c.rewind.push(c.Snapshot())
+		c.rewindSaved = true
+	}
 
+	// the timers run while the instruction waits for a key too
+	defer c.countFrame()
+	c.waitingForKey = false
+
+	instrPC := c.pc
 	opcode := uint16(c.ram[c.pc])<<8 | uint16(c.ram[c.pc+1])
+	c.recent.push(InstrRecord{PC: instrPC, Opcode: opcode})
+
 	typ := uint8((opcode >> 12) & 0x0f)
 	nnn := uint16(opcode & 0x0fff)
 	nn := uint8(opcode & 0x00ff)
@@ -196,17 +426,59 @@ func (c *Chip8) Emulate() {
 		// Returns from a subroutine
 		case 0xee:
 			if c.sp == 0 {
-				log.Println("incorrect stack")
-				os.Exit(1)
+				c.halt(fmt.Errorf("%04X: %w", c.pc-2, ErrStackUnderflow))
+				return
 			}
 
 			c.sp--
 			c.pc = c.stack[c.sp]
 			opcodeString = fmt.Sprintf("return (jump to %04X)", c.pc)
 
+		// 00FB
+		// Scrolls the screen right by 4 pixels (SUPER-CHIP)
+		case 0xfb:
+			c.scrollRight(4)
+			opcodeString = "scroll right by 4"
+
+		// 00FC
+		// Scrolls the screen left by 4 pixels (SUPER-CHIP)
+		case 0xfc:
+			c.scrollLeft(4)
+			opcodeString = "scroll left by 4"
+
+		// 00FD
+		// Exits the interpreter (SUPER-CHIP)
+		case 0xfd:
+			c.state = StateQuit
+			opcodeString = "exit"
+
+		// 00FE
+		// Switches to the 64x32 resolution (SUPER-CHIP), the screen is cleared
+		case 0xfe:
+			c.setHires(false)
+			opcodeString = "low resolution"
+
+		// 00FF
+		// Switches to the 128x64 resolution (SUPER-CHIP), the screen is cleared
+		case 0xff:
+			c.setHires(true)
+			opcodeString = "high resolution"
+
+		// 00CN
+		// Scrolls the screen down by N lines (SUPER-CHIP)
+		//
+		// 0NNN
 		// This instruction is only used on the old computers on which Chip-8 was originally implemented.
 		// It is ignored by modern interpreters.
 		default:
+			if nn&0xf0 == 0xc0 {
+				c.scrollDown(int(n))
+				opcodeString = fmt.Sprintf("scroll down by %d", n)
+				break
+			}
+			if c.unknownOpcode(instrPC, opcode) {
+				return
+			}
 			log.Println("unsupport 0NNN")
 		}
 
@@ -219,9 +491,9 @@ func (c *Chip8) Emulate() {
 	// 2NNN
 	// Calls subroutine at NNN
 	case 0x02:
-		if c.sp == stackMaxSize {
-			log.Println("stack overflow")
-			os.Exit(1)
+		if int(c.sp) == len(c.stack) {
+			c.halt(fmt.Errorf("%04X: %w", c.pc-2, ErrStackOverflow))
+			return
 		}
 		c.stack[c.sp] = c.pc
 		c.sp++
@@ -260,6 +532,9 @@ func (c *Chip8) Emulate() {
 				opcodeString = fmt.Sprintf("continue to %04X because v%X != v%X", c.pc, x, y)
 			}
 		default:
+			if c.unknownOpcode(instrPC, opcode) {
+				return
+			}
 			opcodeString = "undocumented. n must be 0"
 			log.Println("n must be 0 for 5XY0 opcode")
 		}
@@ -326,10 +601,11 @@ func (c *Chip8) Emulate() {
 
 		// 8XY6
 		// If the least-significant bit of Vx is 1, then VF is set to 1, otherwise 0.
-		// Then Vx is divided by 2.
+		// Then Vx is divided by 2. Vy is shifted into Vx instead with the ShiftUsesVY quirk.
 		case 0x06:
-			c.regsV[0xf] = c.regsV[x] & 0x01
-			c.regsV[x] >>= 1
+			src := c.shiftSource(x, y)
+			c.regsV[0xf] = src & 0x01
+			c.regsV[x] = src >> 1
 
 			opcodeString = fmt.Sprintf("V%X >>= 1", x)
 
@@ -348,15 +624,22 @@ func (c *Chip8) Emulate() {
 		// 8XYE
 		// Shifts VX to the left by 1,
 		// then sets VF to 1 if the most significant bit of VX prior to that shift was set,
-		// or to 0 if it was unset
+		// or to 0 if it was unset. Vy is shifted into Vx instead with the ShiftUsesVY quirk.
 		case 0x0e:
+			src := c.shiftSource(x, y)
 			c.regsV[0xf] = 0
-			if c.regsV[x]&0x80 > 0 {
+			if src&0x80 > 0 {
 				c.regsV[0xf] = 1
 			}
-			c.regsV[x] <<= 1
+			c.regsV[x] = src << 1
 
 			opcodeString = fmt.Sprintf("V%X <<= 1", x)
+
+		default:
+			if c.unknownOpcode(instrPC, opcode) {
+				return
+			}
+			opcodeString = fmt.Sprintf("unknown opcode %04X", opcode)
 		}
 
 	case 0x09:
@@ -372,6 +655,9 @@ func (c *Chip8) Emulate() {
 			opcodeString = fmt.Sprintf("if V%X != V%X", x, y)
 
 		default:
+			if c.unknownOpcode(instrPC, opcode) {
+				return
+			}
 			opcodeString = "undocumented. n must be 0"
 			log.Println("n must be 0 for 9XY0 opcode")
 		}
@@ -384,53 +670,80 @@ func (c *Chip8) Emulate() {
 		opcodeString = fmt.Sprintf("vI = %02X", nnn)
 
 	// BNNN
-	// Jumps to the address NNN plus V0
+	// Jumps to the address NNN plus V0.
+	// With the JumpUsesVX quirk, it's BXNN that jumps to the address XNN plus VX (SUPER-CHIP).
 	case 0x0b:
-		c.pc = nnn + uint16(c.regsV[0])
+		c.pc = nnn + uint16(c.jumpOffset(x))
 
 		opcodeString = fmt.Sprintf("jump to %02X", c.pc)
 
 	// CXNN
 	// Sets VX to the result of a bitwise and operation on a random number (Typically: 0 to 255) and NN
 	case 0xc:
-		c.regsV[x] = uint8(v2.IntN(0x100)) & nn
+		c.regsV[x] = c.randomByte() & nn
 
 		opcodeString = fmt.Sprintf("V%X = rnd() & %02X", x, nn)
 
 	// DXYN
 	// Draws a sprite at coordinate (VX, VY) that has a width of 8 pixels and a height of N pixels.
+	// In the high resolution, DXY0 draws a 16x16 sprite of 2 bytes per row (SUPER-CHIP).
+	// In the low resolution, DXY0 draws nothing like on the original Chip-8.
 	// Each row of 8 pixels is read as bit-coded starting from memory location I;
 	// I value does not change after the execution of this instruction.
 	// As described above, VF is set to 1 if any screen pixels are flipped from set to unset when the sprite is drawn,
 	// and to 0 if that does not happen.
 	case 0x0d:
-		posX := int(c.regsV[x] & (screenWidth - 1))
-		posY := int(c.regsV[y] & (screenHeight - 1))
+		// the draw waits for the next frame, the instruction is executed again until then
+		if !c.displayReady() {
+			c.pc -= 2
+			return
+		}
+
+		rows, rowBytes := int(n), 1
+		if n == 0 && c.hires {
+			rows, rowBytes = 16, 2
+		}
+		// the sprite past the end of ram follows the overrun policy
+		addrs, spriteBytes, err := c.ramRangeAddrs(rows * rowBytes)
+		if err != nil {
+			c.halt(fmt.Errorf("%04X: %w", c.pc-2, err))
+			return
+		}
+		rows = min(rows, spriteBytes/rowBytes)
+
+		width, height := c.ScreenSize()
+		posX := int(c.regsV[x]) & (width - 1)
+		posY := int(c.regsV[y]) & (height - 1)
 		c.regsV[0xf] = 0x0
 
-		for i := uint8(0); i < n; i++ {
-			spriteData := c.ram[c.regI+uint16(i)]
+		// font sprites are fine, the rest of the reserved region is not expected to hold sprites
+		if c.diagnostics != nil && c.regI < entryPoint && c.regI+uint16(rows*rowBytes) > uint16(len(font)) {
+			fmt.Fprintf(c.diagnostics, "%04X: draw reads sprite data from the reserved region at %04X\n", c.pc-2, c.regI)
+		}
 
-			posXi := posX
-			for j := int8(7); j >= 0; j-- {
-				sprPixelOn := spriteData&(1<<j) > 0
-				posScreen := posY*screenWidth + posXi
+		for i := 0; i < rows; i++ {
+			row, ok := c.spriteRow(posY, i)
+			if !ok {
+				break
+			}
+			var spriteData uint16
+			for b := 0; b < rowBytes; b++ {
+				spriteData = spriteData<<8 | uint16(c.ram[addrs[i*rowBytes+b]])
+			}
+
+			for j := 0; j < rowBytes*8; j++ {
+				col, ok := c.spriteColumn(posX, j)
+				if !ok {
+					break
+				}
+				sprPixelOn := spriteData&(1<<(rowBytes*8-1-j)) > 0
+				posScreen := row*width + col
 
 				// screen pixel is on and sprite pixel is on, set carry flag
 				if sprPixelOn && c.screen[posScreen] {
 					c.regsV[0xf] = 0x1
 				}
 				c.screen[posScreen] = c.screen[posScreen] != sprPixelOn
-
-				posXi++
-				if posXi >= screenWidth {
-					break
-				}
-			}
-
-			posY++
-			if posY >= screenHeight {
-				break
 			}
 		}
 
@@ -458,6 +771,9 @@ func (c *Chip8) Emulate() {
 			opcodeString = fmt.Sprintf("if keypad[%X] not pressed than skip the next", c.regsV[x])
 
 		default:
+			if c.unknownOpcode(instrPC, opcode) {
+				return
+			}
 			opcodeString = fmt.Sprintf("unknown opcode %04X", opcode)
 			log.Println(opcodeString)
 		}
@@ -476,21 +792,14 @@ func (c *Chip8) Emulate() {
 		// A key press is awaited, and then stored in VX
 		// (blocking operation, all instruction halted until next key event)
 		case 0x0a:
-			var i uint8
-		outer:
-
-			for i = uint8(0); i < keyPadSize; i++ {
-				if c.keyPad[i] {
-					c.regsV[x] = i
-					break outer
-				}
-			}
-
-			if i == keyPadSize {
+			i, ok := c.releasedKey()
+			if !ok {
 				c.pc -= 2
+				c.waitingForKey = true
 				log.Println("waiting to press")
 				return
 			}
+			c.regsV[x] = i
 
 			opcodeString = fmt.Sprintf("%X is pressed", i)
 
@@ -504,7 +813,7 @@ func (c *Chip8) Emulate() {
 		// FX18
 		// Sets the sound timer to VX
 		case 0x18:
-			c.soundTimer = c.regsV[x]
+			c.setSoundTimer(c.regsV[x])
 
 			opcodeString = fmt.Sprintf("sound timer = V%X", c.regsV[x])
 
@@ -526,41 +835,84 @@ func (c *Chip8) Emulate() {
 		// Stores the binary-coded decimal representation of VX,
 		// with the hundreds digit in memory at location in I,
 		// the tens digit at location I+1,
-		// and the ones digit at location I+2
+		// and the ones digit at location I+2.
+		// The digits past the end of ram follow the overrun policy.
 		case 0x33:
-			c100 := c.regsV[x] / 100
-			c10 := (c.regsV[x] - c100*100) / 10
-			c1 := c.regsV[x] - c100*100 - c10*10
-
-			c.ram[c.regI] = c100
-			c.ram[c.regI+1] = c10
-			c.ram[c.regI+2] = c1
+			addrs, fit, err := c.ramRangeAddrs(3)
+			if err != nil {
+				c.halt(fmt.Errorf("%04X: %w", c.pc-2, err))
+				return
+			}
+			v := c.regsV[x]
+			digits := [3]uint8{v / 100, v / 10 % 10, v % 10}
+			for i := 0; i < fit; i++ {
+				c.ram[addrs[i]] = digits[i]
+			}
 
 			opcodeString = fmt.Sprintf("BCD(V%X)", x)
 
 		// FX55
 		// Stores from V0 to VX (including VX) in memory, starting at address I.
 		// The offset from I is increased by 1 for each value written,
-		// but I itself is left unmodified
+		// but I itself is left unmodified. I is increased by X+1 with the MemoryIncrementsI quirk.
+		// The registers past the end of ram follow the overrun policy.
 		case 0x55:
-			for i := uint16(0); i <= uint16(x); i++ {
-				c.ram[c.regI+i] = c.regsV[i]
+			addrs, n, err := c.ramRangeAddrs(int(x) + 1)
+			if err != nil {
+				c.halt(fmt.Errorf("%04X: %w", c.pc-2, err))
+				return
 			}
+			for i := 0; i < n; i++ {
+				c.ram[addrs[i]] = c.regsV[i]
+			}
+			c.incrementMemoryIndex(x)
 
 			opcodeString = fmt.Sprintf("store from V0 to V%X", x)
 
 		// FX65
 		// Fills from V0 to VX (including VX) with values from memory, starting at address I.
 		// The offset from I is increased by 1 for each value read,
-		// but I itself is left unmodified
+		// but I itself is left unmodified. I is increased by X+1 with the MemoryIncrementsI quirk.
+		// The registers past the end of ram follow the overrun policy.
 		case 0x65:
-			for i := uint16(0); i <= uint16(x); i++ {
-				c.regsV[i] = c.ram[c.regI+i]
+			addrs, n, err := c.ramRangeAddrs(int(x) + 1)
+			if err != nil {
+				c.halt(fmt.Errorf("%04X: %w", c.pc-2, err))
+				return
 			}
+			for i := 0; i < n; i++ {
+				c.regsV[i] = c.ram[addrs[i]]
+			}
+			c.incrementMemoryIndex(x)
 
 			opcodeString = fmt.Sprintf("decode from RAM to V0 to V%X", x)
 
+		// FX75
+		// Stores from V0 to VX (including VX) in the flag registers, X is at most 7 (SCHIP)
+		case 0x75:
+			n := min(int(x), flagRegistersSize-1)
+			for i := 0; i <= n; i++ {
+				c.flags[i] = c.regsV[i]
+			}
+			// games may store the flags every frame, they are written by SaveFlags
+			c.flagsDirty = true
+
+			opcodeString = fmt.Sprintf("store from V0 to V%X in flags", n)
+
+		// FX85
+		// Fills from V0 to VX (including VX) with values from the flag registers, X is at most 7 (SCHIP)
+		case 0x85:
+			n := min(int(x), flagRegistersSize-1)
+			for i := 0; i <= n; i++ {
+				c.regsV[i] = c.flags[i]
+			}
+
+			opcodeString = fmt.Sprintf("load from flags to V0 to V%X", n)
+
 		default:
+			if c.unknownOpcode(instrPC, opcode) {
+				return
+			}
 			opcodeString = fmt.Sprintf("unknown opcode %04X", opcode)
 		}
 
@@ -569,54 +921,75 @@ func (c *Chip8) Emulate() {
 
 	}
 
-	if c.delayTimer > 0 {
-		c.delayTimer--
-	}
-	if c.soundTimer > 0 {
-		if c.soundTimer == 1 {
-			log.Println("PLAY SOUND")
-			if c.beepPlayer != nil {
-				c.beepPlayer.Play()
-			}
-		}
-		c.soundTimer--
-	}
-
-	fmt.Printf("%04X: %04X %s\n", c.pc, opcode, opcodeString)
+	c.instructionCount++
+	c.traceInstruction(instrPC, opcode, opcodeString)
 }
 
-var emptyScreen = make([]bool, screenSize)
+// InstructionCount returns the number of the instructions executed since the rom was loaded or reset.
+// Instructions that halted the machine or waited, e.g. for a key or the display, aren't counted.
+func (c Chip8) InstructionCount() uint64 {
+	return c.instructionCount
+}
 
 func (c *Chip8) clearScreen() {
-	copy(c.screen[:], emptyScreen)
+	c.screen = [screenBufferSize]bool{}
 }
 
+// setHires switches the resolution. The pixels are laid out differently in another resolution,
+// so the screen is cleared.
+func (c *Chip8) setHires(hires bool) {
+	c.hires = hires
+	c.clearScreen()
+}
+
+// IsHires reports whether the SUPER-CHIP 128x64 resolution is on.
+func (c Chip8) IsHires() bool {
+	return c.hires
+}
+
+// ScreenWidth returns the width of the current resolution.
 func (c Chip8) ScreenWidth() int {
-	return screenWidth
+	w, _ := c.ScreenSize()
+	return w
 }
 
+// ScreenHeight returns the height of the current resolution.
 func (c Chip8) ScreenHeight() int {
-	return screenHeight
+	_, h := c.ScreenSize()
+	return h
 }
 
+// ScreenSize returns the current resolution, 64x32 or 128x64 in the high resolution mode.
 func (c Chip8) ScreenSize() (width int, height int) {
+	return screenResolution(c.hires)
+}
+
+func screenResolution(hires bool) (width int, height int) {
+	if hires {
+		return hiresScreenWidth, hiresScreenHeight
+	}
 	return screenWidth, screenHeight
 }
 
 func (c Chip8) ScreenPixelSetAt(x, y int) bool {
-	pos := y*screenWidth + x
-	if pos >= 0 && pos < screenSize {
-		return c.screen[pos]
+	width, height := c.ScreenSize()
+	if x >= 0 && x < width && y >= 0 && y < height {
+		return c.screen[y*width+x]
 	}
 	return false
 }
 
-func (c *Chip8) SetKey(key uint8, isPressed bool) {
+// SetKey updates a key of the keypad. Keys out of 0x0-0xF are rejected with ErrInvalidKey.
+func (c *Chip8) SetKey(key uint8, isPressed bool) error {
 	if key >= keyPadSize {
-		log.Println("key is invalid. do nothing")
-		return
+		return fmt.Errorf("set key %X: %w", key, ErrInvalidKey)
+	}
+	if isPressed && !c.keyPad[key] {
+		c.keyPresses++
+		c.keyPressOrder[key] = c.keyPresses
 	}
 	c.keyPad[key] = isPressed
+	return nil
 }
 
 func (c *Chip8) KeyIsPressed(key uint8) bool {
@@ -641,14 +1014,47 @@ func (c Chip8) GetState() State {
 	return c.state
 }
 
+// stopped reports whether the machine can't run any more instructions, it's halted or the rom exited.
+func (c Chip8) stopped() bool {
+	return c.state == StateHalted || c.state == StateQuit
+}
+
+// IsWaitingForKey reports whether FX0A is blocked until a key is pressed and released.
+func (c Chip8) IsWaitingForKey() bool {
+	return c.waitingForKey
+}
+
+// IsIdle reports whether the machine is stuck in a jump to itself,
+// which roms commonly use to stop after they are done.
+func (c Chip8) IsIdle() bool {
+	if c.pc+1 >= ramSizeBytes {
+		return false
+	}
+	opcode := uint16(c.ram[c.pc])<<8 | uint16(c.ram[c.pc+1])
+	return opcode == 0x1000|c.pc
+}
+
 func (c *Chip8) SoundVolumeUp() {
-	if c.beepPlayer != nil {
-		c.beepPlayer.VolumeUp()
+	if player, ok := c.soundPlayer.(volumeController); ok {
+		player.VolumeUp()
 	}
 }
 
 func (c *Chip8) SoundVolumeDown() {
-	if c.beepPlayer != nil {
-		c.beepPlayer.VolumeDown()
+	if player, ok := c.soundPlayer.(volumeController); ok {
+		player.VolumeDown()
+	}
+}
+
+// ToggleSoundMute mutes or unmutes the sound player if it can be muted.
+func (c *Chip8) ToggleSoundMute() {
+	if player, ok := c.soundPlayer.(muteController); ok {
+		player.ToggleMute()
 	}
 }
+
+// IsSoundMuted reports whether the sound player is muted.
+func (c Chip8) IsSoundMuted() bool {
+	player, ok := c.soundPlayer.(muteController)
+	return ok && player.IsMuted()
+}
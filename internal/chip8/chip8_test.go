@@ -1,6 +1,7 @@
 package chip8
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,30 @@ func TestChip8_Emulate(t *testing.T) {
 		}
 	})
 
+	t.Run("00FD", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x01, // v[0] = 1
+				0x00, 0xfd, // exit
+				0x60, 0x02, // v[0] = 2
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		for i := 0; i < 3; i++ {
+			chip8.Emulate()
+		}
+
+		require.Equal(t, StateQuit, chip8.GetState())
+		require.Equal(t, uint8(1), chip8.regsV[0], "nothing runs after the exit")
+		require.NoError(t, chip8.Err())
+
+		chip8.TogglePause()
+		require.Equal(t, StateQuit, chip8.GetState(), "can't be resumed")
+	})
+
 	t.Run("1NNN", func(t *testing.T) {
 		rom := Rom{
 			Data: []byte{
@@ -73,6 +98,42 @@ func TestChip8_Emulate(t *testing.T) {
 		require.False(t, chip8.screen[0], "screen")
 	})
 
+	t.Run("2NNN stack overflow", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x22, 0x00, // call 0x200
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		for i := 0; i < defaultStackSize; i++ {
+			require.NoError(t, chip8.Emulate())
+		}
+
+		err := chip8.Emulate()
+		require.ErrorIs(t, err, ErrStackOverflow)
+		require.Equal(t, StateHalted, chip8.GetState())
+		require.Equal(t, uint8(defaultStackSize), chip8.sp)
+	})
+
+	t.Run("00EE stack underflow", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x00, 0xee, // return
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		err := chip8.Emulate()
+		require.ErrorIs(t, err, ErrStackUnderflow)
+		require.ErrorIs(t, chip8.Err(), ErrStackUnderflow)
+		require.Equal(t, StateHalted, chip8.GetState())
+	})
+
 	t.Run("3XNN", func(t *testing.T) {
 		var expectedV0 uint8 = 0x11
 
@@ -663,4 +724,459 @@ func TestChip8_Emulate(t *testing.T) {
 
 		require.Equal(t, expectedVI, chip8.regI)
 	})
+
+	t.Run("FX33", func(t *testing.T) {
+		tests := []struct {
+			value    uint8
+			expected []byte
+		}{
+			{0, []byte{0, 0, 0}},
+			{9, []byte{0, 0, 9}},
+			{10, []byte{0, 1, 0}},
+			{99, []byte{0, 9, 9}},
+			{100, []byte{1, 0, 0}},
+			{109, []byte{1, 0, 9}},
+			{255, []byte{2, 5, 5}},
+		}
+
+		for _, tt := range tests {
+			rom := Rom{
+				Data: []byte{
+					0xa3, 0x00, // vI = 0x300
+					0x65, tt.value, // v[5] = value
+					0xf5, 0x33, // BCD(v[5])
+				},
+			}
+
+			chip8 := NewChip8()
+			chip8.LoadRom(rom)
+			chip8.ram[0x303] = 0xff
+			for i := 0; i < 3; i++ {
+				chip8.Emulate()
+			}
+
+			require.Equal(t, tt.expected, chip8.ram[0x300:0x303], "BCD of %d", tt.value)
+			require.Equal(t, uint8(0xff), chip8.ram[0x303], "nothing is written past I+2")
+			require.Equal(t, uint16(0x300), chip8.regI, "I isn't changed")
+		}
+	})
+}
+
+func TestChip8_ProgramCounterOutOfRAM(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rom  []byte
+		pc   uint16
+	}{
+		{
+			name: "the last byte",
+			rom: []byte{
+				0x1f, 0xff, // jump to 0xfff, the opcode doesn't fit
+			},
+			pc: 0xfff,
+		},
+		{
+			name: "past the end",
+			rom: []byte{
+				0x60, 0xff, // v[0] = 0xff
+				0xbf, 0xff, // jump to 0xfff + v[0]
+			},
+			pc: 0xfff + 0xff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip8 := NewChip8()
+			chip8.LoadRom(Rom{Data: tt.rom})
+
+			require.NotPanics(t, func() {
+				for i := 0; i < 4; i++ {
+					chip8.Emulate()
+				}
+			})
+			require.Equal(t, StateHalted, chip8.GetState())
+			require.ErrorIs(t, chip8.Err(), ErrPCOutOfRAM)
+			require.Equal(t, tt.pc, chip8.pc)
+		})
+	}
+
+	t.Run("the last opcode", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x1f, 0xfe, // jump to 0xffe
+			},
+		})
+		chip8.ram[0xffe] = 0x60 // v[0] = 0x07
+		chip8.ram[0xfff] = 0x07
+
+		chip8.Emulate()
+		require.NoError(t, chip8.Emulate())
+		require.Equal(t, uint8(0x07), chip8.regsV[0])
+
+		// the next opcode is past the end
+		require.ErrorIs(t, chip8.Emulate(), ErrPCOutOfRAM)
+	})
+}
+
+func TestChip8_Diagnostics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("draw from the reserved region", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0xa1, 0x00, // vI = 0x100
+				0xd0, 0x05, // draw(0, 0, 5)
+			},
+		}
+
+		var diagnostics bytes.Buffer
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetDiagnosticWriter(&diagnostics)
+
+		chip8.Emulate()
+		chip8.Emulate()
+
+		require.Contains(t, diagnostics.String(), "0202: draw reads sprite data from the reserved region at 0100")
+	})
+
+	t.Run("draw a font sprite", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x0a, // v[0] = 0xa
+				0xf0, 0x29, // vI = font sprite of v[0]
+				0xd0, 0x05, // draw(0, 0, 5)
+			},
+		}
+
+		var diagnostics bytes.Buffer
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetDiagnosticWriter(&diagnostics)
+
+		chip8.Emulate()
+		chip8.Emulate()
+		chip8.Emulate()
+
+		require.Empty(t, diagnostics.String())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0xa1, 0x00, // vI = 0x100
+				0xd0, 0x05, // draw(0, 0, 5)
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		require.NotPanics(t, func() {
+			chip8.Emulate()
+			chip8.Emulate()
+		})
+	})
+}
+
+func TestChip8_SetKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid key", func(t *testing.T) {
+		chip8 := NewChip8()
+
+		require.NoError(t, chip8.SetKey(0xf, true))
+		require.True(t, chip8.KeyIsPressed(0xf))
+
+		require.NoError(t, chip8.SetKey(0xf, false))
+		require.False(t, chip8.KeyIsPressed(0xf))
+	})
+
+	t.Run("out of range key", func(t *testing.T) {
+		chip8 := NewChip8()
+
+		err := chip8.SetKey(keyPadSize, true)
+		require.ErrorIs(t, err, ErrInvalidKey)
+		require.Equal(t, [keyPadSize]bool{}, chip8.keyPad)
+	})
+}
+
+func TestChip8_Activity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("waiting for key", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0xf0, 0x0a, // v[0] = pressed key
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		chip8.Emulate()
+		require.True(t, chip8.IsWaitingForKey())
+
+		require.NoError(t, chip8.SetKey(0x5, true))
+		chip8.Emulate()
+		require.True(t, chip8.IsWaitingForKey(), "waits for the release")
+
+		require.NoError(t, chip8.SetKey(0x5, false))
+		chip8.Emulate()
+		require.False(t, chip8.IsWaitingForKey())
+		require.Equal(t, uint8(0x5), chip8.regsV[0])
+	})
+
+	t.Run("idle self jump", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x11, // 0x200: v[0] = 0x11
+				0x12, 0x02, // 0x202: jump to 0x202
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		require.False(t, chip8.IsIdle())
+
+		chip8.Emulate()
+		require.True(t, chip8.IsIdle())
+
+		chip8.Emulate()
+		require.True(t, chip8.IsIdle())
+	})
+}
+
+func TestChip8_LoadRom(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fits ram", func(t *testing.T) {
+		rom := Rom{Data: make([]byte, romMaxSizeBytes)}
+		rom.Data[romMaxSizeBytes-1] = 0xab
+
+		chip8 := NewChip8()
+		require.NoError(t, chip8.LoadRom(rom))
+		require.Equal(t, uint8(0xab), chip8.ram[ramSizeBytes-1])
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		rom := Rom{Data: make([]byte, romMaxSizeBytes+1)}
+		rom.Data[0] = 0xab
+
+		chip8 := NewChip8()
+		err := chip8.LoadRom(rom)
+		require.ErrorIs(t, err, ErrRomTooLarge)
+		require.Equal(t, uint8(0), chip8.ram[entryPoint])
+		require.Empty(t, chip8.GetRom().Data)
+	})
+
+	t.Run("clears the previous rom", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xff, // high resolution
+				0x60, 0x11, // v[0] = 0x11
+				0xa3, 0x00, // vI = 0x300
+				0x22, 0x0a, // call 0x20a
+				0x00, 0x00,
+				0xf0, 0x15, // 0x20a: delay timer = v[0]
+				0xd0, 0x05, // draw(v[0], v[0], 5)
+				0xff, 0xff, // 0x20e: nothing the second rom overwrites
+			},
+		})
+		for i := 0; i < 6; i++ {
+			chip8.Emulate()
+		}
+		require.NoError(t, chip8.SetKey(0x3, true))
+		require.Equal(t, uint8(1), chip8.sp)
+
+		rom := Rom{
+			Data: []byte{
+				0x00, 0xe0, // clear screen
+			},
+		}
+		require.NoError(t, chip8.LoadRom(rom))
+
+		fresh := NewChip8()
+		fresh.LoadRom(rom)
+		require.Equal(t, fresh.Snapshot(), chip8.Snapshot())
+		require.Equal(t, font, chip8.ram[:len(font)])
+		require.Equal(t, uint8(0), chip8.ram[0x20e], "no bytes of the previous rom are left")
+		require.Equal(t, uint64(0), chip8.InstructionCount())
+	})
+}
+
+func TestChip8_Timers(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x0a, // v[0] = 0x0a
+			0x61, 0x14, // v[1] = 0x14
+			0xf0, 0x15, // delay timer = v[0]
+			0xf1, 0x18, // sound timer = v[1]
+		},
+	}
+
+	chip8 := NewChip8()
+	chip8.LoadRom(rom)
+	chip8.SetTPS(FramesPerSecond)
+	require.Equal(t, uint8(0), chip8.DelayTimer())
+	require.Equal(t, uint8(0), chip8.SoundTimer())
+
+	for i := 0; i < 4; i++ {
+		chip8.step()
+	}
+
+	// the timers tick once per frame (tps/60 instructions), at 60 tps after every instruction,
+	// so they were decremented after being set
+	require.Equal(t, uint8(0x0a-2), chip8.DelayTimer())
+	require.Equal(t, uint8(0x14-1), chip8.SoundTimer())
+}
+
+func TestChip8_InstructionCount(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x70, 0x01, // 0x200: v[0] += 1
+			0x30, 0x05, // 0x202: skip if v[0] == 5
+			0x12, 0x00, // 0x204: jump to 0x200
+			0xf1, 0x0a, // 0x206: wait for a key
+		},
+	}
+
+	chip8 := NewChip8()
+	chip8.SetTPS(maxTPS)
+	chip8.LoadRom(rom)
+	require.Equal(t, uint64(0), chip8.InstructionCount())
+
+	// 4 loops of 3 instructions and the last add and skip
+	for i := 0; i < 4*3+2; i++ {
+		require.NoError(t, chip8.Emulate())
+	}
+	require.Equal(t, uint64(14), chip8.InstructionCount())
+
+	// waiting for a key isn't counted
+	chip8.Emulate()
+	chip8.Emulate()
+	require.True(t, chip8.IsWaitingForKey())
+	require.Equal(t, uint64(14), chip8.InstructionCount())
+
+	chip8.Reset()
+	require.Equal(t, uint64(0), chip8.InstructionCount())
+
+	chip8.Emulate()
+	chip8.LoadRom(rom)
+	require.Equal(t, uint64(0), chip8.InstructionCount())
+}
+
+func TestChip8_ScreenPixelSetAt(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	chip8.screen[0] = true
+	chip8.screen[1*screenWidth+2] = true
+	chip8.screen[screenSize-1] = true
+
+	require.True(t, chip8.ScreenPixelSetAt(0, 0))
+	require.True(t, chip8.ScreenPixelSetAt(2, 1))
+	require.False(t, chip8.ScreenPixelSetAt(1, 2), "the screen is indexed by rows")
+	require.True(t, chip8.ScreenPixelSetAt(screenWidth-1, screenHeight-1))
+	require.False(t, chip8.ScreenPixelSetAt(-1, 0))
+	require.False(t, chip8.ScreenPixelSetAt(0, -1))
+	require.False(t, chip8.ScreenPixelSetAt(screenWidth, 0))
+}
+
+func TestChip8_TogglePause(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	require.Equal(t, StateRunning, chip8.GetState())
+
+	chip8.TogglePause()
+	require.Equal(t, StatePaused, chip8.GetState())
+
+	chip8.TogglePause()
+	require.Equal(t, StateRunning, chip8.GetState())
+
+	chip8.halt(ErrMemoryOverrun)
+	chip8.TogglePause()
+	require.Equal(t, StateHalted, chip8.GetState(), "a halted machine can't be resumed")
+}
+
+type fakeVolumePlayer struct {
+	fakeSoundPlayer
+	volume int
+	muted  bool
+}
+
+func (p *fakeVolumePlayer) ToggleMute() {
+	p.muted = !p.muted
+}
+
+func (p *fakeVolumePlayer) IsMuted() bool {
+	return p.muted
+}
+
+func (p *fakeVolumePlayer) VolumeUp() {
+	p.volume++
+}
+
+func (p *fakeVolumePlayer) VolumeDown() {
+	p.volume--
+}
+
+func TestChip8_SoundVolume(t *testing.T) {
+	t.Parallel()
+
+	t.Run("proxied to the player", func(t *testing.T) {
+		player := &fakeVolumePlayer{}
+		chip8 := NewChip8()
+		chip8.SetSoundPlayer(player)
+
+		chip8.SoundVolumeUp()
+		chip8.SoundVolumeUp()
+		chip8.SoundVolumeDown()
+		require.Equal(t, 1, player.volume)
+	})
+
+	t.Run("player without volume", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.SetSoundPlayer(&fakeSoundPlayer{})
+
+		require.NotPanics(t, chip8.SoundVolumeUp)
+		require.NotPanics(t, chip8.SoundVolumeDown)
+		require.NotPanics(t, chip8.ToggleSoundMute)
+		require.False(t, chip8.IsSoundMuted())
+	})
+
+	t.Run("mute", func(t *testing.T) {
+		player := &fakeVolumePlayer{}
+		chip8 := NewChip8()
+		chip8.SetSoundPlayer(player)
+
+		chip8.ToggleSoundMute()
+		require.True(t, chip8.IsSoundMuted())
+		chip8.ToggleSoundMute()
+		require.False(t, chip8.IsSoundMuted())
+	})
+}
+
+func TestState_String(t *testing.T) {
+	t.Parallel()
+
+	tests := map[State]string{
+		StateRunning: "Running",
+		StatePaused:  "Paused",
+		StateHalted:  "Halted",
+		StateQuit:    "Quit",
+		State(42):    "State(42)",
+	}
+	for state, expected := range tests {
+		require.Equal(t, expected, state.String())
+	}
 }
@@ -0,0 +1,34 @@
+package chip8
+
+import "time"
+
+// Clock is the source of time for pacing the emulation, see Run.
+type Clock interface {
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks at intervals, like time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+type systemClock struct{}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// SetClock replaces the system clock, e.g. with a fake one in tests.
+func (c *Chip8) SetClock(clock Clock) {
+	c.clock = clock
+}
@@ -0,0 +1,130 @@
+package chip8
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock moves only when it's advanced, the due ticks are delivered by Advance
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+	// the tickers are sent here when they are created
+	created chan *fakeTicker
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     time.Unix(0, 0),
+		created: make(chan *fakeTicker, 1),
+	}
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	t := &fakeTicker{clock: c, c: make(chan time.Time), resets: make(chan time.Duration, 1), interval: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	c.mu.Unlock()
+
+	c.created <- t
+	return t
+}
+
+// Advance moves the time forward by d. The ticks are sent one by one as they become due,
+// every send waits until the tick is received.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		var due *fakeTicker
+		for _, t := range c.tickers {
+			if !t.stopped && !t.next.After(end) && (due == nil || t.next.Before(due.next)) {
+				due = t
+			}
+		}
+		if due == nil {
+			break
+		}
+
+		c.now = due.next
+		due.next = due.next.Add(due.interval)
+		c.mu.Unlock()
+		due.c <- c.now
+		c.mu.Lock()
+	}
+	c.now = end
+	c.mu.Unlock()
+}
+
+type fakeTicker struct {
+	clock *fakeClock
+	c     chan time.Time
+	// the intervals of Reset are sent here
+	resets   chan time.Duration
+	interval time.Duration
+	next     time.Time
+	stopped  bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.interval = d
+	t.next = t.clock.now.Add(d)
+
+	select {
+	case t.resets <- d:
+	default:
+	}
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
+
+func TestChip8_SetMaxIPS_Run(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x70, 0x01, // 0x200: v[0] += 1
+			0x12, 0x00, // 0x202: jump to 0x200
+		},
+	}
+
+	// runs the machine for d on the fake clock,
+	// returns the interval of the ticker and the number of executed instructions
+	run := func(t *testing.T, tps, maxIPS int, d time.Duration) (time.Duration, uint64) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetTPS(tps)
+		chip8.SetMaxIPS(maxIPS)
+
+		clock, ticker, _, stop := runFake(t, &chip8)
+		advance(clock, d)
+		stop()
+		return ticker.interval, chip8.InstructionCount()
+	}
+
+	t.Run("spaces instructions", func(t *testing.T) {
+		interval, count := run(t, 2000, 500, 20*time.Millisecond)
+		require.Equal(t, 2*time.Millisecond, interval)
+		require.Equal(t, uint64(10), count)
+	})
+
+	t.Run("not limited", func(t *testing.T) {
+		interval, count := run(t, 1000, 0, 20*time.Millisecond)
+		require.Equal(t, time.Millisecond, interval, "paced by tps only")
+		require.Equal(t, uint64(20), count)
+	})
+}
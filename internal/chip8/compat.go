@@ -0,0 +1,53 @@
+package chip8
+
+// Compatibility lists groups of instructions found in a rom
+// whose behavior differs between CHIP-8 interpreters.
+//
+// see more https://chip8.gulrak.net/#quirk5
+type Compatibility struct {
+	// 8XY6 and 8XYE shift VX in place or shift VY into VX
+	Shift bool
+	// FX55 and FX65 leave I unchanged or increment it
+	MemoryIncrement bool
+	// BNNN jumps to NNN + V0 or to XNN + VX
+	Jump bool
+}
+
+// ScanCompatibility walks the rom opcode by opcode and reports which quirk-sensitive
+// instructions it uses. The scan is linear, so sprite data may be reported as well.
+func (r Rom) ScanCompatibility() Compatibility {
+	var compat Compatibility
+
+	for i := 0; i+1 < len(r.Data); i += 2 {
+		opcode := uint16(r.Data[i])<<8 | uint16(r.Data[i+1])
+		typ := uint8((opcode >> 12) & 0x0f)
+		nn := uint8(opcode & 0x00ff)
+		n := uint8(opcode & 0x000f)
+
+		switch {
+		case typ == 0x08 && (n == 0x06 || n == 0x0e):
+			compat.Shift = true
+		case typ == 0x0f && (nn == 0x55 || nn == 0x65):
+			compat.MemoryIncrement = true
+		case typ == 0x0b:
+			compat.Jump = true
+		}
+	}
+
+	return compat
+}
+
+// Warnings returns a human readable line for every quirk-sensitive group.
+func (c Compatibility) Warnings() []string {
+	var warnings []string
+	if c.Shift {
+		warnings = append(warnings, "8XY6/8XYE: shift source differs (VX or VY)")
+	}
+	if c.MemoryIncrement {
+		warnings = append(warnings, "FX55/FX65: I may or may not be incremented")
+	}
+	if c.Jump {
+		warnings = append(warnings, "BNNN: jump offset differs (V0 or VX)")
+	}
+	return warnings
+}
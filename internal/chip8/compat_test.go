@@ -0,0 +1,52 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRom_ScanCompatibility(t *testing.T) {
+	t.Parallel()
+
+	t.Run("quirk sensitive opcodes", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x80, 0x16, // v[0] >>= 1
+				0xf2, 0x55, // store from v[0] to v[2]
+				0xb2, 0x00, // jump to v[0] + 0x200
+			},
+		}
+
+		compat := rom.ScanCompatibility()
+
+		require.Equal(t, Compatibility{Shift: true, MemoryIncrement: true, Jump: true}, compat)
+		require.Len(t, compat.Warnings(), 3)
+	})
+
+	t.Run("8XYE and FX65", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x80, 0x1e, // v[0] <<= 1
+				0xf2, 0x65, // load from RAM to v[0]..v[2]
+			},
+		}
+
+		require.Equal(t, Compatibility{Shift: true, MemoryIncrement: true}, rom.ScanCompatibility())
+	})
+
+	t.Run("safe opcodes", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x11, // v[0] = 0x11
+				0x80, 0x14, // v[0] += v[1]
+				0x12, 0x00, // jump to 0x200
+			},
+		}
+
+		compat := rom.ScanCompatibility()
+
+		require.Equal(t, Compatibility{}, compat)
+		require.Empty(t, compat.Warnings())
+	})
+}
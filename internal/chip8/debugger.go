@@ -0,0 +1,53 @@
+package chip8
+
+// the number of instructions StepBack can undo
+const stepHistoryDepth = 256
+
+// Step executes a single instruction even if the machine is paused.
+// Instructions undone with StepBack are replayed exactly, ignoring the current input.
+func (c *Chip8) Step() {
+	if c.stepHistory == nil {
+		c.stepHistory = newRewindBuffer(stepHistoryDepth)
+	}
+	c.stepHistory.push(c.Snapshot())
+
+	if n := len(c.stepRedo); n > 0 {
+		c.Restore(c.stepRedo[n-1])
+		c.stepRedo = c.stepRedo[:n-1]
+		return
+	}
+	c.step()
+}
+
+// StepFrame executes the instructions of a single frame at the tps with Step, then ends the frame.
+// Every instruction can be undone with StepBack. It stops early if the machine halts.
+func (c *Chip8) StepFrame() {
+	for i := 0; i < c.instructionsPerFrame() && !c.stopped(); i++ {
+		c.Step()
+	}
+	c.EndFrame()
+}
+
+// StepBack undoes the last instruction executed by Step. The instruction and frame counters
+// and the timer phase are rolled back with it.
+// It returns false if the history is empty.
+func (c *Chip8) StepBack() bool {
+	if c.stepHistory == nil {
+		return false
+	}
+
+	s, ok := c.stepHistory.pop()
+	if !ok {
+		return false
+	}
+	c.stepRedo = append(c.stepRedo, c.Snapshot())
+	c.Restore(s)
+	return true
+}
+
+func (c *Chip8) clearStepHistory() {
+	if c.stepHistory != nil {
+		c.stepHistory.size = 0
+	}
+	c.stepRedo = nil
+}
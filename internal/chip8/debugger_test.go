@@ -0,0 +1,160 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_Step(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x70, 0x01, // 0x200: v[0] += 1
+			0x12, 0x00, // 0x202: jump to 0x200
+		},
+	}
+
+	t.Run("an instruction per step", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.TogglePause()
+
+		for i := 1; i <= 4; i++ {
+			chip8.Step()
+			require.Equal(t, uint64(i), chip8.InstructionCount())
+		}
+		require.Equal(t, uint8(2), chip8.regsV[0])
+		require.Equal(t, uint16(0x200), chip8.pc)
+		require.Equal(t, StatePaused, chip8.GetState())
+	})
+
+	t.Run("a frame per step", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		require.NoError(t, chip8.SetCyclesPerFrame(10))
+		chip8.TogglePause()
+
+		chip8.StepFrame()
+		require.Equal(t, uint64(10), chip8.InstructionCount())
+		require.Equal(t, uint8(5), chip8.regsV[0])
+
+		// the instructions of the frame are undone one by one
+		require.True(t, chip8.StepBack())
+		require.Equal(t, uint16(0x202), chip8.pc)
+	})
+}
+
+func TestChip8_StepBack(t *testing.T) {
+	t.Parallel()
+
+	t.Run("forward and back", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x11, // v[0] = 0x11
+				0x61, 0x22, // v[1] = 0x22
+				0x80, 0x14, // v[0] += v[1]
+				0xa2, 0x00, // vI = 0x200
+				0xd0, 0x15, // draw(0, 1, 5)
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.TogglePause()
+
+		var states []Snapshot
+		for i := 0; i < 5; i++ {
+			states = append(states, chip8.Snapshot())
+			chip8.Step()
+		}
+		final := chip8.Snapshot()
+		require.Equal(t, uint16(0x20a), chip8.pc)
+
+		for i := len(states) - 1; i >= 0; i-- {
+			require.True(t, chip8.StepBack())
+			require.Equal(t, states[i], chip8.Snapshot())
+		}
+		require.False(t, chip8.StepBack(), "history is empty")
+
+		for i := 1; i < len(states); i++ {
+			chip8.Step()
+			require.Equal(t, states[i], chip8.Snapshot())
+		}
+		chip8.Step()
+		require.Equal(t, final, chip8.Snapshot())
+		require.Equal(t, StatePaused, chip8.GetState())
+	})
+
+	t.Run("bounded by history depth", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x70, 0x01, // 0x200: v[0] += 1
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		for i := 0; i < stepHistoryDepth+10; i++ {
+			chip8.Step()
+		}
+
+		steps := 0
+		for chip8.StepBack() {
+			steps++
+		}
+		require.Equal(t, stepHistoryDepth, steps)
+	})
+
+	t.Run("counters are rolled back", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x70, 0x01, // 0x200: v[0] += 1
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetTPS(2 * FramesPerSecond)
+		chip8.TogglePause()
+
+		// a frame and a half
+		for i := 0; i < 3; i++ {
+			chip8.Step()
+		}
+		require.Equal(t, uint64(3), chip8.InstructionCount())
+		require.Equal(t, uint64(1), chip8.FrameCount())
+
+		require.True(t, chip8.StepBack())
+		require.Equal(t, uint64(2), chip8.InstructionCount())
+		require.Equal(t, uint64(1), chip8.FrameCount())
+		require.Equal(t, 0, chip8.frameTime)
+
+		require.True(t, chip8.StepBack())
+		require.Equal(t, uint64(1), chip8.InstructionCount())
+		require.Equal(t, uint64(0), chip8.FrameCount())
+		require.Equal(t, FramesPerSecond, chip8.frameTime, "half a frame in")
+	})
+
+	t.Run("history is dropped on emulation", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x11, // v[0] = 0x11
+				0x61, 0x22, // v[1] = 0x22
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		chip8.Step()
+		chip8.Emulate()
+
+		require.False(t, chip8.StepBack())
+		require.Equal(t, uint8(0x22), chip8.regsV[1])
+	})
+}
@@ -0,0 +1,203 @@
+package chip8
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Disassemble returns the listing of the rom loaded at the entry point.
+//
+// Only the bytes reachable from the entry point through jumps, calls, and skips
+// are decoded as instructions. The rest, e.g. sprites, is listed as DB byte directives.
+// Targets of BNNN depend on V0 at run time, so they can't be followed.
+func Disassemble(rom Rom) string {
+	code := reachableCode(rom.Data)
+
+	var sb strings.Builder
+	for i := 0; i < len(rom.Data); {
+		addr := entryPoint + i
+
+		if code[i] && i+1 < len(rom.Data) {
+			opcode := uint16(rom.Data[i])<<8 | uint16(rom.Data[i+1])
+			fmt.Fprintf(&sb, "%03X: %04X  %s\n", addr, opcode, DisassembleOpcode(opcode))
+			i += 2
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%03X: %02X    DB 0x%02X\n", addr, rom.Data[i], rom.Data[i])
+		i++
+	}
+
+	return sb.String()
+}
+
+// reachableCode marks the bytes of data that start an instruction reachable from the entry point.
+func reachableCode(data []byte) []bool {
+	code := make([]bool, len(data))
+
+	queue := []uint16{entryPoint}
+	for len(queue) > 0 {
+		addr := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		if addr < entryPoint {
+			continue
+		}
+		i := int(addr - entryPoint)
+		if i+1 >= len(data) || code[i] {
+			continue
+		}
+		code[i] = true
+
+		opcode := uint16(data[i])<<8 | uint16(data[i+1])
+		typ := uint8((opcode >> 12) & 0x0f)
+		nnn := uint16(opcode & 0x0fff)
+		nn := uint8(opcode & 0x00ff)
+		next := addr + 2
+
+		switch {
+		// return and exit end the flow
+		case opcode == 0x00ee, opcode == 0x00fd:
+
+		// jump
+		case typ == 0x01:
+			queue = append(queue, nnn)
+
+		// jump with offset, the target is unknown
+		case typ == 0x0b:
+
+		// call
+		case typ == 0x02:
+			queue = append(queue, nnn, next)
+
+		// skip the next instruction
+		case typ == 0x03, typ == 0x04, typ == 0x05, typ == 0x09,
+			typ == 0x0e && (nn == 0x9e || nn == 0xa1):
+			queue = append(queue, next, next+2)
+
+		default:
+			queue = append(queue, next)
+		}
+	}
+
+	return code
+}
+
+// DisassembleOpcode returns the mnemonic of a single opcode.
+//
+// see more http://devernay.free.fr/hacks/chip8/C8TECH10.HTM#3.1
+func DisassembleOpcode(opcode uint16) string {
+	typ := uint8((opcode >> 12) & 0x0f)
+	nnn := uint16(opcode & 0x0fff)
+	nn := uint8(opcode & 0x00ff)
+	n := uint8(opcode & 0x000f)
+	x := uint8((opcode >> 8) & 0x0f)
+	y := uint8((opcode >> 4) & 0x0f)
+
+	switch typ {
+	case 0x00:
+		switch opcode {
+		case 0x00e0:
+			return "CLS"
+		case 0x00ee:
+			return "RET"
+		case 0x00fb:
+			return "SCR"
+		case 0x00fc:
+			return "SCL"
+		case 0x00fd:
+			return "EXIT"
+		case 0x00fe:
+			return "LOW"
+		case 0x00ff:
+			return "HIGH"
+		}
+		if opcode&0xfff0 == 0x00c0 {
+			return fmt.Sprintf("SCD %d", n)
+		}
+		return fmt.Sprintf("SYS 0x%03X", nnn)
+	case 0x01:
+		return fmt.Sprintf("JP 0x%03X", nnn)
+	case 0x02:
+		return fmt.Sprintf("CALL 0x%03X", nnn)
+	case 0x03:
+		return fmt.Sprintf("SE V%X, 0x%02X", x, nn)
+	case 0x04:
+		return fmt.Sprintf("SNE V%X, 0x%02X", x, nn)
+	case 0x05:
+		if n == 0x0 {
+			return fmt.Sprintf("SE V%X, V%X", x, y)
+		}
+	case 0x06:
+		return fmt.Sprintf("LD V%X, 0x%02X", x, nn)
+	case 0x07:
+		return fmt.Sprintf("ADD V%X, 0x%02X", x, nn)
+	case 0x08:
+		switch n {
+		case 0x0:
+			return fmt.Sprintf("LD V%X, V%X", x, y)
+		case 0x1:
+			return fmt.Sprintf("OR V%X, V%X", x, y)
+		case 0x2:
+			return fmt.Sprintf("AND V%X, V%X", x, y)
+		case 0x3:
+			return fmt.Sprintf("XOR V%X, V%X", x, y)
+		case 0x4:
+			return fmt.Sprintf("ADD V%X, V%X", x, y)
+		case 0x5:
+			return fmt.Sprintf("SUB V%X, V%X", x, y)
+		case 0x6:
+			return fmt.Sprintf("SHR V%X, V%X", x, y)
+		case 0x7:
+			return fmt.Sprintf("SUBN V%X, V%X", x, y)
+		case 0xe:
+			return fmt.Sprintf("SHL V%X, V%X", x, y)
+		}
+	case 0x09:
+		if n == 0x0 {
+			return fmt.Sprintf("SNE V%X, V%X", x, y)
+		}
+	case 0x0a:
+		return fmt.Sprintf("LD I, 0x%03X", nnn)
+	case 0x0b:
+		return fmt.Sprintf("JP V0, 0x%03X", nnn)
+	case 0x0c:
+		return fmt.Sprintf("RND V%X, 0x%02X", x, nn)
+	case 0x0d:
+		return fmt.Sprintf("DRW V%X, V%X, %d", x, y, n)
+	case 0x0e:
+		switch nn {
+		case 0x9e:
+			return fmt.Sprintf("SKP V%X", x)
+		case 0xa1:
+			return fmt.Sprintf("SKNP V%X", x)
+		}
+	case 0x0f:
+		switch nn {
+		case 0x07:
+			return fmt.Sprintf("LD V%X, DT", x)
+		case 0x0a:
+			return fmt.Sprintf("LD V%X, K", x)
+		case 0x15:
+			return fmt.Sprintf("LD DT, V%X", x)
+		case 0x18:
+			return fmt.Sprintf("LD ST, V%X", x)
+		case 0x1e:
+			return fmt.Sprintf("ADD I, V%X", x)
+		case 0x29:
+			return fmt.Sprintf("LD F, V%X", x)
+		case 0x33:
+			return fmt.Sprintf("LD B, V%X", x)
+		case 0x55:
+			return fmt.Sprintf("LD [I], V%X", x)
+		case 0x65:
+			return fmt.Sprintf("LD V%X, [I]", x)
+		case 0x75:
+			return fmt.Sprintf("LD R, V%X", x)
+		case 0x85:
+			return fmt.Sprintf("LD V%X, R", x)
+		}
+	}
+
+	return fmt.Sprintf("DW 0x%04X", opcode)
+}
@@ -0,0 +1,83 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisassemble(t *testing.T) {
+	t.Parallel()
+
+	t.Run("inline sprite data", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0xa2, 0x06, // 0x200: vI = 0x206
+				0xd0, 0x12, // 0x202: draw(0, 1, 2)
+				0x12, 0x04, // 0x204: jump to 0x204
+				0xff, 0x81, // 0x206: sprite
+			},
+		}
+
+		expected := "" +
+			"200: A206  LD I, 0x206\n" +
+			"202: D012  DRW V0, V1, 2\n" +
+			"204: 1204  JP 0x204\n" +
+			"206: FF    DB 0xFF\n" +
+			"207: 81    DB 0x81\n"
+
+		require.Equal(t, expected, Disassemble(rom))
+	})
+
+	t.Run("calls and skips", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x22, 0x08, // 0x200: call 0x208
+				0x30, 0x01, // 0x202: if v[0] == 0x01 then skip the next instruction
+				0x12, 0x0c, // 0x204: jump to 0x20c
+				0x12, 0x0e, // 0x206: jump to 0x20e
+				0x60, 0x01, // 0x208: v[0] = 0x01
+				0x00, 0xee, // 0x20a: return
+				0x3c, 0x42, // 0x20c: if v[c] == 0x42 then skip the next instruction
+				0x12, 0x0e, // 0x20e: jump to 0x20e
+			},
+		}
+
+		expected := "" +
+			"200: 2208  CALL 0x208\n" +
+			"202: 3001  SE V0, 0x01\n" +
+			"204: 120C  JP 0x20C\n" +
+			"206: 120E  JP 0x20E\n" +
+			"208: 6001  LD V0, 0x01\n" +
+			"20A: 00EE  RET\n" +
+			"20C: 3C42  SE VC, 0x42\n" +
+			"20E: 120E  JP 0x20E\n"
+
+		require.Equal(t, expected, Disassemble(rom))
+	})
+}
+
+func TestDisassembleOpcode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[uint16]string{
+		0x00e0: "CLS",
+		0x00ee: "RET",
+		0x00c3: "SCD 3",
+		0x00fb: "SCR",
+		0x00fc: "SCL",
+		0x00fd: "EXIT",
+		0x00fe: "LOW",
+		0x00ff: "HIGH",
+		0x1234: "JP 0x234",
+		0x6a11: "LD VA, 0x11",
+		0x8126: "SHR V1, V2",
+		0xd015: "DRW V0, V1, 5",
+		0xf233: "LD B, V2",
+		0xf375: "LD R, V3",
+		0x5121: "DW 0x5121",
+	}
+	for opcode, expected := range tests {
+		require.Equal(t, expected, DisassembleOpcode(opcode))
+	}
+}
@@ -0,0 +1,87 @@
+package chip8
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// SCHIP has 8 flag registers that FX75/FX85 save and restore V0-VX to.
+// Games keep high scores in them.
+const flagRegistersSize = 8
+
+// FlagStorage keeps the flag registers of a rom between runs.
+type FlagStorage interface {
+	Load() ([flagRegistersSize]uint8, error)
+	Save(flags [flagRegistersSize]uint8) error
+}
+
+// FlagFile stores the flag registers of a rom in a file named after the rom hash,
+// so every rom has its own flags regardless of the rom file name.
+type FlagFile struct {
+	path string
+}
+
+func NewFlagFile(dir string, rom Rom) FlagFile {
+	return FlagFile{
+		path: filepath.Join(dir, rom.Hash()+".flags"),
+	}
+}
+
+// Load reads the flags from the file. Flags of a rom that has never saved them are zero.
+func (f FlagFile) Load() ([flagRegistersSize]uint8, error) {
+	var flags [flagRegistersSize]uint8
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return flags, nil
+	}
+	if err != nil {
+		return flags, fmt.Errorf("read flags from %s: %w", f.path, err)
+	}
+
+	copy(flags[:], data)
+	return flags, nil
+}
+
+// Save writes the flags to the file, the directory is created if needed.
+func (f FlagFile) Save(flags [flagRegistersSize]uint8) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("create flags dir: %w", err)
+	}
+	if err := os.WriteFile(f.path, flags[:], 0o644); err != nil {
+		return fmt.Errorf("write flags to %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// SetFlagStorage enables persistence of the flag registers: they are loaded from storage now
+// and saved by SaveFlags after FX75 changed them. nil disables persistence.
+func (c *Chip8) SetFlagStorage(storage FlagStorage) error {
+	c.flagStorage = storage
+	if storage == nil {
+		return nil
+	}
+
+	flags, err := storage.Load()
+	if err != nil {
+		return fmt.Errorf("load flags: %w", err)
+	}
+	c.flags = flags
+	return nil
+}
+
+// SaveFlags writes the flag registers to the flag storage, e.g. on exit. LoadRom calls it too.
+// It does nothing if persistence is disabled or FX75 hasn't changed the flags since the last save.
+func (c *Chip8) SaveFlags() error {
+	if c.flagStorage == nil || !c.flagsDirty {
+		return nil
+	}
+	if err := c.flagStorage.Save(c.flags); err != nil {
+		return fmt.Errorf("save flags: %w", err)
+	}
+	c.flagsDirty = false
+	return nil
+}
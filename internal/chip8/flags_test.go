@@ -0,0 +1,99 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_FlagStorage(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0xf0, 0x85, // 0x200: restore v[0] from flags
+			0x70, 0x01, // 0x202: v[0] += 1, the game is played once more
+			0x61, 0x2a, // 0x204: v[1] = 0x2a
+			0xf1, 0x75, // 0x206: store v[0]-v[1] in flags
+		},
+	}
+	dir := t.TempDir()
+
+	run := func() Chip8 {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		require.NoError(t, chip8.SetFlagStorage(NewFlagFile(dir, rom)))
+		for i := 0; i < len(rom.Data)/2; i++ {
+			chip8.step()
+		}
+		// on exit
+		require.NoError(t, chip8.SaveFlags())
+		return chip8
+	}
+
+	first := run()
+	require.Equal(t, [flagRegistersSize]uint8{0x01, 0x2a}, first.flags)
+
+	// restart, the flags saved by the first run are restored
+	second := run()
+	require.Equal(t, [flagRegistersSize]uint8{0x02, 0x2a}, second.flags)
+
+	t.Run("another rom", func(t *testing.T) {
+		other := Rom{Data: []byte{0x00, 0xe0}}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(other)
+		require.NoError(t, chip8.SetFlagStorage(NewFlagFile(dir, other)))
+		require.Equal(t, [flagRegistersSize]uint8{}, chip8.flags)
+	})
+
+	t.Run("without storage", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		for i := 0; i < len(rom.Data)/2; i++ {
+			chip8.step()
+		}
+		require.Equal(t, [flagRegistersSize]uint8{0x01, 0x2a}, chip8.flags)
+		require.NoError(t, chip8.SaveFlags())
+	})
+
+	t.Run("saved on demand", func(t *testing.T) {
+		storage := &countingStorage{}
+		loop := Rom{
+			Data: []byte{
+				0xf1, 0x75, // 0x200: store v[0]-v[1] in flags
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(loop)
+		require.NoError(t, chip8.SetFlagStorage(storage))
+		for i := 0; i < 100; i++ {
+			chip8.step()
+		}
+		require.Zero(t, storage.saves, "FX75 doesn't write the storage")
+
+		require.NoError(t, chip8.SaveFlags())
+		require.NoError(t, chip8.SaveFlags())
+		require.Equal(t, 1, storage.saves, "the unchanged flags aren't written again")
+
+		chip8.step()
+		require.NoError(t, chip8.LoadRom(loop))
+		require.Equal(t, 2, storage.saves, "the flags are saved before the rom is reloaded")
+	})
+}
+
+// countingStorage counts the saves of the flags
+type countingStorage struct {
+	saves int
+}
+
+func (s *countingStorage) Load() ([flagRegistersSize]uint8, error) {
+	return [flagRegistersSize]uint8{}, nil
+}
+
+func (s *countingStorage) Save([flagRegistersSize]uint8) error {
+	s.saves++
+	return nil
+}
@@ -0,0 +1,31 @@
+package chip8
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 16 hex digit sprites of 5 rows
+const fontSizeBytes = 16 * 5
+
+var ErrInvalidFont = errors.New("invalid font")
+
+// SetFont replaces the sprites of the hex digits that FX29 points to, e.g. to theme the digits.
+// The font is 5 bytes per digit from 0 to F. It's copied to low memory right away and by every load and reset.
+func (c *Chip8) SetFont(f []byte) error {
+	if len(f) != fontSizeBytes {
+		return fmt.Errorf("%w: got %d bytes, must be %d bytes of 16 sprites", ErrInvalidFont, len(f), fontSizeBytes)
+	}
+
+	c.customFont = append([]byte(nil), f...)
+	copy(c.ram[:], c.customFont)
+	return nil
+}
+
+// font returns the custom font or the default one.
+func (c Chip8) font() []byte {
+	if c.customFont != nil {
+		return c.customFont
+	}
+	return font
+}
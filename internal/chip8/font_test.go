@@ -0,0 +1,55 @@
+package chip8
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SetFont(t *testing.T) {
+	t.Parallel()
+
+	t.Run("draws the custom glyph", func(t *testing.T) {
+		// every digit is a box with the hollow middle row
+		custom := bytes.Repeat([]byte{0xF0, 0xF0, 0x90, 0xF0, 0xF0}, 16)
+
+		chip8 := NewChip8()
+		require.NoError(t, chip8.SetFont(custom))
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x60, 0x07, // v[0] = 7
+				0xf0, 0x29, // vI = font sprite of v[0]
+				0xd1, 0x15, // draw(v[1], v[1], 5)
+			},
+		})
+		for i := 0; i < 3; i++ {
+			chip8.Emulate()
+		}
+
+		require.Equal(t, uint16(7*5), chip8.regI)
+		for row, bits := range []uint8{0xF0, 0xF0, 0x90, 0xF0, 0xF0} {
+			for col := 0; col < 8; col++ {
+				expected := bits&(0x80>>col) != 0
+				require.Equal(t, expected, chip8.ScreenPixelSetAt(col, row), "pixel (%d, %d)", col, row)
+			}
+		}
+	})
+
+	t.Run("kept by reset", func(t *testing.T) {
+		custom := bytes.Repeat([]byte{0xAA}, fontSizeBytes)
+
+		chip8 := NewChip8()
+		require.NoError(t, chip8.SetFont(custom))
+		chip8.LoadRom(Rom{Data: []byte{0x00, 0xe0}})
+		chip8.Reset()
+
+		require.Equal(t, custom, chip8.ram[:fontSizeBytes])
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		chip8 := NewChip8()
+		require.ErrorIs(t, chip8.SetFont(make([]byte, 79)), ErrInvalidFont)
+		require.Equal(t, font, chip8.ram[:fontSizeBytes])
+	})
+}
@@ -0,0 +1,57 @@
+package chip8
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// RunHeadless executes the loaded rom without a window for at most maxInstructions instructions.
+// It returns earlier once the rom is done: it exits with 00FD, jumps to itself, or waits for a key
+// that nobody presses. A frame ends every tps/60 instructions, so the timers run and the screen
+// can be captured with CaptureFrameImage. The error that halts the machine is returned.
+// It runs even if the machine is paused.
+func (c *Chip8) RunHeadless(maxInstructions int) error {
+	ipf := c.instructionsPerFrame()
+	for i := 0; i < maxInstructions && !c.stopped(); i++ {
+		if c.IsIdle() || c.IsWaitingForKey() {
+			break
+		}
+		c.step()
+		if (i+1)%ipf == 0 {
+			c.EndFrame()
+		}
+	}
+	c.EndFrame()
+	return c.err
+}
+
+// ScreenText returns the screen of the current resolution as text, e.g. for CI logs or golden files.
+// A set pixel is #, the others are dots, and every pixel row ends with a new line.
+func (c Chip8) ScreenText() string {
+	return c.screenGrid('#', '.')
+}
+
+// ScreenString returns the screen of the current resolution drawn with full blocks and spaces
+// for terminals and readable test failures. Every pixel row ends with a new line.
+func (c Chip8) ScreenString() string {
+	return c.screenGrid('█', ' ')
+}
+
+// screenGrid returns the screen with a rune per pixel and a line per pixel row.
+func (c Chip8) screenGrid(on, off rune) string {
+	width, height := c.ScreenSize()
+
+	var sb strings.Builder
+	sb.Grow((width*utf8.RuneLen(on) + 1) * height)
+	for y := 0; y < height; y++ {
+		for _, set := range c.screen[y*width : (y+1)*width] {
+			if set {
+				sb.WriteRune(on)
+			} else {
+				sb.WriteRune(off)
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
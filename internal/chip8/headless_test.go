@@ -0,0 +1,141 @@
+package chip8
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_RunHeadless(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs to the self jump", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x02, // 0x200: v[0] = 2
+				0xa0, 0x05, // 0x202: vI = 0x005, font sprite of 1
+				0xd0, 0x05, // 0x204: draw(2, 2, 5)
+				0x12, 0x06, // 0x206: jump to 0x206
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		require.NoError(t, chip8.RunHeadless(1000))
+		require.Equal(t, uint64(3), chip8.InstructionCount(), "the self jump isn't run")
+
+		// 1 is 0x20, 0x60, 0x20, 0x20, 0x70 drawn at (2, 2)
+		lines := strings.Split(chip8.ScreenText(), "\n")
+		require.Len(t, lines, screenHeight+1)
+		require.Equal(t, "", lines[screenHeight])
+		require.Equal(t, []string{
+			"..........",
+			"..........",
+			"....#.....",
+			"...##.....",
+			"....#.....",
+			"....#.....",
+			"...###....",
+			"..........",
+		}, firstColumns(lines[:8], 10))
+		require.Equal(t, strings.Repeat(".", screenWidth), lines[screenHeight-1])
+	})
+
+	t.Run("stops at the instruction limit", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x70, 0x01, // 0x200: v[0] += 1
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		require.NoError(t, chip8.RunHeadless(10))
+		require.Equal(t, uint64(10), chip8.InstructionCount())
+		require.Equal(t, uint8(5), chip8.regsV[0])
+	})
+
+	t.Run("stops on exit and key wait", func(t *testing.T) {
+		for _, opcode := range []byte{0xfd, 0x0a} {
+			chip8 := NewChip8()
+			chip8.LoadRom(Rom{
+				Data: []byte{
+					0xf0, 0x0a, // wait for a key or exit
+					0x60, 0x01, // v[0] = 1
+				},
+			})
+			if opcode == 0xfd {
+				chip8.ram[entryPoint], chip8.ram[entryPoint+1] = 0x00, 0xfd
+			}
+
+			require.NoError(t, chip8.RunHeadless(1000))
+			require.Equal(t, uint8(0), chip8.regsV[0])
+		}
+	})
+
+	t.Run("returns the halt error", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xee, // return with the empty stack
+			},
+		})
+
+		require.ErrorIs(t, chip8.RunHeadless(1000), ErrStackUnderflow)
+	})
+}
+
+// firstColumns returns the first n characters of the lines
+func firstColumns(lines []string, n int) []string {
+	columns := make([]string, len(lines))
+	for i, line := range lines {
+		columns[i] = line[:n]
+	}
+	return columns
+}
+
+func TestChip8_ScreenString(t *testing.T) {
+	t.Parallel()
+
+	t.Run("draws the sprite", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0xa0, 0x00, // 0x200: vI = 0x000, font sprite of 0
+				0xd0, 0x05, // 0x202: draw(0, 0, 5)
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.RunHeadless(2)
+
+		// 0 is 0xf0, 0x90, 0x90, 0x90, 0xf0
+		pad := strings.Repeat(" ", screenWidth-4)
+		expected := "████" + pad + "\n" +
+			"█  █" + pad + "\n" +
+			"█  █" + pad + "\n" +
+			"█  █" + pad + "\n" +
+			"████" + pad + "\n" +
+			strings.Repeat(strings.Repeat(" ", screenWidth)+"\n", screenHeight-5)
+		require.Equal(t, expected, chip8.ScreenString())
+	})
+
+	t.Run("respects the hires resolution", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x00, 0xff, // 0x200: hires
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.RunHeadless(1)
+
+		expected := strings.Repeat(strings.Repeat(" ", hiresScreenWidth)+"\n", hiresScreenHeight)
+		require.Equal(t, expected, chip8.ScreenString())
+	})
+}
@@ -0,0 +1,144 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_Hires(t *testing.T) {
+	t.Parallel()
+
+	t.Run("switch modes", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xff, // 0x200: high resolution
+				0x00, 0xfe, // 0x202: low resolution
+			},
+		})
+		require.False(t, chip8.IsHires())
+		w, h := chip8.ScreenSize()
+		require.Equal(t, screenWidth, w)
+		require.Equal(t, screenHeight, h)
+
+		chip8.step()
+		require.True(t, chip8.IsHires())
+		w, h = chip8.ScreenSize()
+		require.Equal(t, hiresScreenWidth, w)
+		require.Equal(t, hiresScreenHeight, h)
+
+		chip8.step()
+		require.False(t, chip8.IsHires())
+		require.Equal(t, screenWidth, chip8.ScreenWidth())
+		require.Equal(t, screenHeight, chip8.ScreenHeight())
+	})
+
+	t.Run("pixel addressing", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xff, // 0x200: high resolution
+				0x60, 0x7c, // 0x202: v[0] = 124
+				0x61, 0x3b, // 0x204: v[1] = 59
+				0xa0, 0x00, // 0x206: vI = font sprite of 0
+				0xd0, 0x15, // 0x208: draw(v[0], v[1], 5)
+			},
+		})
+		for i := 0; i < 5; i++ {
+			chip8.step()
+		}
+
+		// the sprite is drawn in the bottom right corner, out of the low resolution area
+		require.True(t, chip8.ScreenPixelSetAt(124, 59))
+		require.True(t, chip8.ScreenPixelSetAt(127, 63))
+		require.True(t, chip8.screen[63*hiresScreenWidth+127])
+		require.False(t, chip8.ScreenPixelSetAt(128, 63), "out of the screen")
+		require.False(t, chip8.ScreenPixelSetAt(0, 64), "out of the screen")
+	})
+
+	t.Run("16x16 sprite", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xff, // 0x200: high resolution
+				0xa2, 0x0c, // 0x202: vI = 0x20c
+				0xd0, 0x00, // 0x204: draw(v[0], v[0], 16x16)
+				0x61, 0x08, // 0x206: v[1] = 8
+				0xd1, 0x10, // 0x208: draw(v[1], v[1], 16x16)
+				0x12, 0x0a, // 0x20a: jump to 0x20a
+				// 0x20c: 16 rows, the left half is lit and the right half is a checker
+				0xff, 0xaa, 0xff, 0xaa, 0xff, 0xaa, 0xff, 0xaa,
+				0xff, 0xaa, 0xff, 0xaa, 0xff, 0xaa, 0xff, 0xaa,
+				0xff, 0xaa, 0xff, 0xaa, 0xff, 0xaa, 0xff, 0xaa,
+				0xff, 0xaa, 0xff, 0xaa, 0xff, 0xaa, 0xff, 0xaa,
+			},
+		})
+		for i := 0; i < 3; i++ {
+			chip8.step()
+		}
+
+		require.Equal(t, uint8(0), chip8.regsV[0xf])
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 8; x++ {
+				require.True(t, chip8.ScreenPixelSetAt(x, y))
+			}
+			for x := 8; x < 16; x++ {
+				require.Equal(t, x%2 == 0, chip8.ScreenPixelSetAt(x, y))
+			}
+		}
+		require.False(t, chip8.ScreenPixelSetAt(16, 0))
+		require.False(t, chip8.ScreenPixelSetAt(0, 16))
+
+		// the second sprite overlaps the checker of the first one
+		chip8.step()
+		chip8.step()
+		require.Equal(t, uint8(1), chip8.regsV[0xf])
+		require.False(t, chip8.ScreenPixelSetAt(8, 8), "both sprites are lit here")
+		require.True(t, chip8.ScreenPixelSetAt(9, 8), "only the second sprite is lit here")
+	})
+
+	t.Run("DXY0 draws nothing in the low resolution", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0xa0, 0x00, // 0x200: vI = font sprite of 0
+				0xd0, 0x00, // 0x202: draw(v[0], v[0], 0)
+			},
+		})
+		chip8.step()
+		chip8.step()
+
+		require.Equal(t, [screenBufferSize]bool{}, chip8.screen)
+		require.Equal(t, uint8(0), chip8.regsV[0xf])
+	})
+
+	t.Run("clear screen covers the high resolution", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xff, // 0x200: high resolution
+				0x00, 0xe0, // 0x202: clear screen
+			},
+		})
+		chip8.step()
+		chip8.screen[0] = true
+		chip8.screen[screenBufferSize-1] = true
+
+		chip8.step()
+		require.Equal(t, [screenBufferSize]bool{}, chip8.screen)
+	})
+
+	t.Run("switching clears the screen", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xff, // 0x200: high resolution
+			},
+		})
+		chip8.screen[0] = true
+
+		chip8.step()
+		require.False(t, chip8.ScreenPixelSetAt(0, 0))
+	})
+}
@@ -0,0 +1,22 @@
+package chip8
+
+// SetIdleFunc sets the func called when Emulate executes a 1NNN jump to itself, the end of most roms.
+// Front-ends can stop emulating or throttle it down, nothing but the timers changes from then on.
+// It's called once with the address of the jump until the machine runs elsewhere, e.g. after a reset.
+// Loops that wait for a key, e.g. with EX9E or FX0A, aren't self jumps. nil disables it and is default.
+func (c *Chip8) SetIdleFunc(f func(pc uint16)) {
+	c.idleFunc = f
+}
+
+// reportIdle calls the idle func if the instruction at pc was a jump to itself the first time in a row.
+func (c *Chip8) reportIdle(pc uint16) {
+	if c.pc != pc || !c.IsIdle() {
+		c.idleReported = false
+		return
+	}
+	if c.idleReported || c.idleFunc == nil {
+		return
+	}
+	c.idleReported = true
+	c.idleFunc(pc)
+}
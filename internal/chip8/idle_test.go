@@ -0,0 +1,83 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SetIdleFunc(t *testing.T) {
+	t.Parallel()
+
+	newChip8 := func(rom Rom) (*Chip8, *[]uint16) {
+		chip8 := NewChip8()
+		chip8.SetTPS(maxTPS)
+		chip8.LoadRom(rom)
+
+		var calls []uint16
+		chip8.SetIdleFunc(func(pc uint16) {
+			calls = append(calls, pc)
+		})
+		return &chip8, &calls
+	}
+
+	t.Run("self jump", func(t *testing.T) {
+		chip8, calls := newChip8(Rom{
+			Data: []byte{
+				0x60, 0x01, // 0x200: v[0] = 1
+				0x12, 0x02, // 0x202: jump to 0x202
+			},
+		})
+
+		chip8.Emulate()
+		require.Empty(t, *calls, "the jump isn't executed yet")
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, chip8.Emulate())
+		}
+		require.Equal(t, []uint16{0x202}, *calls, "called once")
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.True(t, chip8.IsIdle())
+
+		chip8.Reset()
+		for i := 0; i < 2; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, []uint16{0x202, 0x202}, *calls, "called again after the reset")
+	})
+
+	t.Run("jump back", func(t *testing.T) {
+		chip8, calls := newChip8(Rom{
+			Data: []byte{
+				0x70, 0x01, // 0x200: v[0] += 1
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		})
+
+		for i := 0; i < 10; i++ {
+			chip8.Emulate()
+		}
+		require.Empty(t, *calls)
+	})
+
+	t.Run("key wait loops", func(t *testing.T) {
+		chip8, calls := newChip8(Rom{
+			Data: []byte{
+				0xe0, 0xa1, // 0x200: skip the next if key v[0] isn't pressed
+				0x12, 0x06, // 0x202: jump to 0x206
+				0x12, 0x00, // 0x204: jump to 0x200
+				0xf0, 0x0a, // 0x206: wait for a key
+			},
+		})
+
+		for i := 0; i < 10; i++ {
+			chip8.Emulate()
+		}
+		require.NoError(t, chip8.SetKey(0x0, true))
+		for i := 0; i < 10; i++ {
+			chip8.Emulate()
+		}
+		require.True(t, chip8.IsWaitingForKey())
+		require.Empty(t, *calls)
+	})
+}
@@ -0,0 +1,53 @@
+package chip8
+
+import (
+	"image"
+	"image/color"
+)
+
+// CaptureImage renders the current screen to an image.
+// Set pixels are painted with fg, the others with bg. Every pixel is scaled to a square of scale size,
+// scale less than 1 is treated as 1.
+//
+// The screen may be captured in the middle of a frame, e.g. after a sprite is erased but before
+// it's redrawn. Use CaptureFrameImage to capture the screen as it was presented.
+func (c Chip8) CaptureImage(fg, bg color.Color, scale int) image.Image {
+	return screenImage(&c.screen, c.hires, fg, bg, scale)
+}
+
+// EndFrame marks the frame boundary: the current screen is the one presented to the user
+// until the next call. Renderers call it once per emulated frame, after its instructions.
+func (c *Chip8) EndFrame() {
+	c.frame = c.screen
+	c.frameHires = c.hires
+	c.frameEnded = true
+}
+
+// CaptureFrameImage renders the screen as of the last EndFrame, like CaptureImage does.
+// The current screen is rendered if no frame has ended yet.
+func (c Chip8) CaptureFrameImage(fg, bg color.Color, scale int) image.Image {
+	if !c.frameEnded {
+		return c.CaptureImage(fg, bg, scale)
+	}
+	return screenImage(&c.frame, c.frameHires, fg, bg, scale)
+}
+
+func screenImage(screen *[screenBufferSize]bool, hires bool, fg, bg color.Color, scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+
+	width, height := screenResolution(hires)
+	img := image.NewRGBA(image.Rect(0, 0, width*scale, height*scale))
+	for y := 0; y < height*scale; y++ {
+		for x := 0; x < width*scale; x++ {
+			pixelColor := bg
+			if screen[(y/scale)*width+x/scale] {
+				pixelColor = fg
+			}
+			img.Set(x, y, pixelColor)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,81 @@
+package chip8
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_CaptureImage(t *testing.T) {
+	t.Parallel()
+
+	fg := color.RGBA{R: 0xff, G: 0x80, A: 0xff}
+	bg := color.RGBA{B: 0x40, A: 0xff}
+
+	chip8 := NewChip8()
+	// top left and bottom right corners
+	chip8.screen[0] = true
+	chip8.screen[screenSize-1] = true
+
+	t.Run("scaled", func(t *testing.T) {
+		scale := 3
+
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, chip8.CaptureImage(fg, bg, scale)))
+		img, err := png.Decode(&buf)
+		require.NoError(t, err)
+
+		w, h := screenWidth*scale, screenHeight*scale
+		require.Equal(t, w, img.Bounds().Dx())
+		require.Equal(t, h, img.Bounds().Dy())
+
+		require.Equal(t, fg, color.RGBAModel.Convert(img.At(0, 0)))
+		require.Equal(t, fg, color.RGBAModel.Convert(img.At(scale-1, scale-1)))
+		require.Equal(t, bg, color.RGBAModel.Convert(img.At(w-1, 0)))
+		require.Equal(t, bg, color.RGBAModel.Convert(img.At(0, h-1)))
+		require.Equal(t, fg, color.RGBAModel.Convert(img.At(w-1, h-1)))
+		require.Equal(t, bg, color.RGBAModel.Convert(img.At(w-scale-1, h-1)))
+	})
+
+	t.Run("scale less than 1", func(t *testing.T) {
+		img := chip8.CaptureImage(fg, bg, 0)
+		require.Equal(t, screenWidth, img.Bounds().Dx())
+		require.Equal(t, screenHeight, img.Bounds().Dy())
+	})
+}
+
+func TestChip8_CaptureFrameImage(t *testing.T) {
+	t.Parallel()
+
+	fg := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	bg := color.RGBA{A: 0xff}
+
+	chip8 := NewChip8()
+	chip8.LoadRom(Rom{
+		Data: []byte{
+			0xa0, 0x00, // vI = 0x000, font sprite of 0
+			0xd0, 0x05, // draw(0, 0, 5)
+			0x00, 0xe0, // clear screen
+		},
+	})
+
+	// no frame has ended yet, the current screen is captured
+	require.Equal(t, bg, color.RGBAModel.Convert(chip8.CaptureFrameImage(fg, bg, 1).At(0, 0)))
+
+	chip8.step()
+	chip8.step()
+	chip8.EndFrame()
+
+	// the screen is cleared in the middle of the next frame
+	chip8.step()
+	require.Equal(t, bg, color.RGBAModel.Convert(chip8.CaptureImage(fg, bg, 1).At(0, 0)))
+
+	// the presented frame still has the sprite
+	img := chip8.CaptureFrameImage(fg, bg, 1)
+	require.Equal(t, fg, color.RGBAModel.Convert(img.At(0, 0)))
+	require.Equal(t, fg, color.RGBAModel.Convert(img.At(3, 4)))
+	require.Equal(t, bg, color.RGBAModel.Convert(img.At(1, 1)))
+}
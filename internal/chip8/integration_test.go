@@ -0,0 +1,58 @@
+package chip8
+
+import (
+	_ "embed"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	//go:embed testdata/IBM_Logo.ch8
+	ibmLogoRom []byte
+	//go:embed testdata/test_opcode.ch8
+	opcodeTestRom []byte
+)
+
+// TestRoms runs the test roms headlessly till their result screen settles
+// and compares it to the known-good one of the default interpreter behavior.
+func TestRoms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+		// hash of the final screen, see ScreenHash
+		expectedHash string
+	}{
+		{
+			name:         "IBM logo",
+			data:         ibmLogoRom,
+			expectedHash: "d4598c296d5884a621d3fb2bc9461a308710fcfa",
+		},
+		{
+			// corax89 opcode test: every tested opcode is marked OK
+			name:         "opcode test",
+			data:         opcodeTestRom,
+			expectedHash: "d858f4e1618523ea26185fc3553b43b1ec605475",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chip8 := NewChip8()
+			chip8.SetTraceWriter(nil)
+			// the hashes are of the default behavior, whatever the defaults become
+			chip8.SetQuirks(Quirks{})
+			require.NoError(t, chip8.LoadRom(Rom{Data: tt.data}))
+
+			_, ok := chip8.RunUntilStable(100_000, 10, color.White, color.Black, 1)
+			require.True(t, ok, "the screen didn't settle")
+			require.True(t, chip8.IsIdle(), "the rom didn't finish")
+			require.Equal(t, tt.expectedHash, chip8.ScreenHash())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package chip8
+
+// KeyWaitPolicy selects the key that FX0A waits for when several keys are pressed at once.
+type KeyWaitPolicy int
+
+const (
+	// KeyWaitLowest stores the pressed key with the lowest index.
+	KeyWaitLowest KeyWaitPolicy = iota
+	// KeyWaitLatest stores the most recently pressed key.
+	KeyWaitLatest
+)
+
+func (p KeyWaitPolicy) String() string {
+	switch p {
+	case KeyWaitLowest:
+		return "lowest"
+	case KeyWaitLatest:
+		return "latest"
+	}
+	return ""
+}
+
+// SetKeyWaitPolicy sets the key that FX0A waits for when several keys are pressed. KeyWaitLowest is default.
+func (c *Chip8) SetKeyWaitPolicy(policy KeyWaitPolicy) {
+	c.keyWaitPolicy = policy
+}
+
+// pressedKey returns the key that FX0A stores according to the key wait policy.
+// false is returned if no key is pressed.
+func (c Chip8) pressedKey() (uint8, bool) {
+	key, found := uint8(0), false
+	for i := uint8(0); i < keyPadSize; i++ {
+		if !c.keyPad[i] {
+			continue
+		}
+		if c.keyWaitPolicy == KeyWaitLowest {
+			return i, true
+		}
+		if !found || c.keyPressOrder[i] > c.keyPressOrder[key] {
+			key, found = i, true
+		}
+	}
+	return key, found
+}
+
+// releasedKey returns the key that FX0A stores. Like on the original interpreter, FX0A completes
+// only once the pressed key is released, so a held key isn't read again by the next FX0A.
+// The key is chosen on the press according to the key wait policy. false is returned while waiting.
+func (c *Chip8) releasedKey() (uint8, bool) {
+	if !c.keyWaitPressed {
+		key, ok := c.pressedKey()
+		if ok {
+			c.keyWaitKey, c.keyWaitPressed = key, true
+		}
+		return 0, false
+	}
+
+	if c.keyPad[c.keyWaitKey] {
+		return 0, false
+	}
+	c.keyWaitPressed = false
+	return c.keyWaitKey, true
+}
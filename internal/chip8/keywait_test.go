@@ -0,0 +1,98 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_KeyWaitPolicy(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0xf0, 0x0a, // v[0] = pressed key
+		},
+	}
+
+	tests := []struct {
+		policy      KeyWaitPolicy
+		expectedKey uint8
+	}{
+		{policy: KeyWaitLowest, expectedKey: 0x3},
+		{policy: KeyWaitLatest, expectedKey: 0x9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			chip8 := NewChip8()
+			chip8.LoadRom(rom)
+			chip8.SetKeyWaitPolicy(tt.policy)
+
+			// 0x9 is pressed after 0x3 while 0x3 is still held
+			require.NoError(t, chip8.SetKey(0x3, true))
+			require.NoError(t, chip8.SetKey(0x9, true))
+			chip8.step()
+
+			require.NoError(t, chip8.SetKey(0x3, false))
+			require.NoError(t, chip8.SetKey(0x9, false))
+			chip8.step()
+			require.Equal(t, tt.expectedKey, chip8.regsV[0])
+		})
+	}
+
+	t.Run("held key isn't pressed again", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetKeyWaitPolicy(KeyWaitLatest)
+
+		require.NoError(t, chip8.SetKey(0x3, true))
+		require.NoError(t, chip8.SetKey(0x9, true))
+		// a repeated report of the held key doesn't count as a new press
+		require.NoError(t, chip8.SetKey(0x3, true))
+		chip8.step()
+
+		require.NoError(t, chip8.SetKey(0x9, false))
+		chip8.step()
+		require.Equal(t, uint8(0x9), chip8.regsV[0])
+	})
+
+	t.Run("completes on release", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0xf3, 0x0a, // 0x200: v[3] = pressed key
+				0xf4, 0x0a, // 0x202: v[4] = pressed key
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		// nothing is pressed yet
+		chip8.Emulate()
+		require.Equal(t, uint16(0x200), chip8.pc)
+
+		// the held key keeps the instruction waiting
+		require.NoError(t, chip8.SetKey(0x7, true))
+		for i := 0; i < 3; i++ {
+			chip8.Emulate()
+			require.Equal(t, uint16(0x200), chip8.pc)
+			require.Equal(t, uint8(0), chip8.regsV[3])
+		}
+
+		// a press of another key while waiting doesn't change the key
+		require.NoError(t, chip8.SetKey(0x2, true))
+		require.NoError(t, chip8.SetKey(0x7, false))
+		chip8.Emulate()
+		require.Equal(t, uint16(0x202), chip8.pc)
+		require.Equal(t, uint8(0x7), chip8.regsV[3])
+
+		// the next FX0A gets the key that is still held only after its release
+		chip8.Emulate()
+		require.Equal(t, uint16(0x202), chip8.pc)
+		require.NoError(t, chip8.SetKey(0x2, false))
+		chip8.Emulate()
+		require.Equal(t, uint16(0x204), chip8.pc)
+		require.Equal(t, uint8(0x2), chip8.regsV[4])
+	})
+}
@@ -0,0 +1,48 @@
+package chip8
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrReservedMemory is returned by WriteMemory below the entry point, where the interpreter and the font live.
+var ErrReservedMemory = errors.New("memory is reserved for the interpreter")
+
+// ReadMemory returns the byte of ram at addr.
+func (c Chip8) ReadMemory(addr uint16) (byte, error) {
+	if int(addr) >= ramSizeBytes {
+		return 0, fmt.Errorf("read %04X: %w", addr, ErrMemoryOverrun)
+	}
+	return c.ram[addr], nil
+}
+
+// ReadMemoryRange returns a copy of length bytes of ram from start.
+// The whole range must fit in ram.
+func (c Chip8) ReadMemoryRange(start, length uint16) ([]byte, error) {
+	if int(start)+int(length) > ramSizeBytes {
+		return nil, fmt.Errorf("read %04X..%04X: %w", start, int(start)+int(length), ErrMemoryOverrun)
+	}
+	data := make([]byte, length)
+	copy(data, c.ram[start:])
+	return data, nil
+}
+
+// WriteMemory sets the byte of ram at addr, e.g. for cheats and tools.
+// The interpreter area below 0x200 with the font is rejected with ErrReservedMemory unless it's allowed,
+// see SetAllowReservedWrites.
+func (c *Chip8) WriteMemory(addr uint16, b byte) error {
+	if int(addr) >= ramSizeBytes {
+		return fmt.Errorf("write %04X: %w", addr, ErrMemoryOverrun)
+	}
+	if addr < entryPoint && !c.allowReservedWrites {
+		return fmt.Errorf("write %04X: %w", addr, ErrReservedMemory)
+	}
+	c.ram[addr] = b
+	return nil
+}
+
+// SetAllowReservedWrites sets whether WriteMemory can change the interpreter area, e.g. to patch the font.
+// It's disallowed by default.
+func (c *Chip8) SetAllowReservedWrites(allow bool) {
+	c.allowReservedWrites = allow
+}
@@ -0,0 +1,106 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_ReadMemory(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	chip8.LoadRom(Rom{
+		Data: []byte{
+			0x60, 0x11, // 0x200: v[0] = 0x11
+		},
+	})
+	chip8.ram[ramSizeBytes-1] = 0xab
+
+	t.Run("byte", func(t *testing.T) {
+		b, err := chip8.ReadMemory(0x201)
+		require.NoError(t, err)
+		require.Equal(t, byte(0x11), b)
+
+		b, err = chip8.ReadMemory(ramSizeBytes - 1)
+		require.NoError(t, err)
+		require.Equal(t, byte(0xab), b)
+
+		_, err = chip8.ReadMemory(ramSizeBytes)
+		require.ErrorIs(t, err, ErrMemoryOverrun)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		data, err := chip8.ReadMemoryRange(0, 5)
+		require.NoError(t, err)
+		require.Equal(t, font[:5], data)
+
+		data, err = chip8.ReadMemoryRange(ramSizeBytes-2, 2)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x00, 0xab}, data)
+
+		data, err = chip8.ReadMemoryRange(ramSizeBytes, 0)
+		require.NoError(t, err)
+		require.Empty(t, data)
+
+		_, err = chip8.ReadMemoryRange(ramSizeBytes-2, 3)
+		require.ErrorIs(t, err, ErrMemoryOverrun)
+		_, err = chip8.ReadMemoryRange(0xffff, 0xffff)
+		require.ErrorIs(t, err, ErrMemoryOverrun)
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		data, err := chip8.ReadMemoryRange(0x200, 2)
+		require.NoError(t, err)
+
+		data[0] = 0xff
+		require.Equal(t, byte(0x60), chip8.ram[0x200])
+	})
+}
+
+func TestChip8_WriteMemory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("byte", func(t *testing.T) {
+		chip8 := NewChip8()
+
+		require.NoError(t, chip8.WriteMemory(0x200, 0x12))
+		require.NoError(t, chip8.WriteMemory(ramSizeBytes-1, 0xab))
+		require.Equal(t, byte(0x12), chip8.ram[0x200])
+		require.Equal(t, byte(0xab), chip8.ram[ramSizeBytes-1])
+	})
+
+	t.Run("out of ram", func(t *testing.T) {
+		chip8 := NewChip8()
+		before := chip8.ram
+
+		require.ErrorIs(t, chip8.WriteMemory(ramSizeBytes, 0xff), ErrMemoryOverrun)
+		require.ErrorIs(t, chip8.WriteMemory(0xffff, 0xff), ErrMemoryOverrun)
+		require.Equal(t, before, chip8.ram)
+	})
+
+	t.Run("reserved", func(t *testing.T) {
+		chip8 := NewChip8()
+
+		require.ErrorIs(t, chip8.WriteMemory(0x000, 0xff), ErrReservedMemory)
+		require.ErrorIs(t, chip8.WriteMemory(entryPoint-1, 0xff), ErrReservedMemory)
+		require.Equal(t, font[0], chip8.ram[0])
+
+		chip8.SetAllowReservedWrites(true)
+		require.NoError(t, chip8.WriteMemory(0x000, 0xff))
+		require.Equal(t, byte(0xff), chip8.ram[0])
+	})
+
+	t.Run("runs the written opcode", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x60, 0x11, // 0x200: v[0] = 0x11
+			},
+		})
+
+		require.NoError(t, chip8.WriteMemory(0x201, 0x22))
+		chip8.Emulate()
+		require.Equal(t, uint8(0x22), chip8.regsV[0])
+	})
+}
@@ -0,0 +1,81 @@
+package chip8
+
+import "io"
+
+// Option configures the machine built by NewChip8WithOptions, see the setters of the same names.
+type Option func(c *Chip8) error
+
+// NewChip8WithOptions returns the machine of NewChip8 configured with the options in order.
+// The first option that fails is returned as the error.
+func NewChip8WithOptions(opts ...Option) (Chip8, error) {
+	chip8 := NewChip8()
+	for _, opt := range opts {
+		if err := opt(&chip8); err != nil {
+			return Chip8{}, err
+		}
+	}
+	return chip8, nil
+}
+
+// WithTPS sets how many instructions run per second, see SetTPS.
+func WithTPS(tps int) Option {
+	return func(c *Chip8) error {
+		c.SetTPS(tps)
+		return nil
+	}
+}
+
+// WithQuirks sets the quirks of the instructions, see SetQuirks.
+func WithQuirks(quirks Quirks) Option {
+	return func(c *Chip8) error {
+		c.SetQuirks(quirks)
+		return nil
+	}
+}
+
+// WithRandSeed makes the random numbers of CXNN repeatable, see SetRandSeed.
+func WithRandSeed(seed uint64) Option {
+	return func(c *Chip8) error {
+		c.SetRandSeed(seed)
+		return nil
+	}
+}
+
+// WithSoundPlayer sets the player of the beep, see SetSoundPlayer.
+func WithSoundPlayer(player SoundPlayer) Option {
+	return func(c *Chip8) error {
+		c.SetSoundPlayer(player)
+		return nil
+	}
+}
+
+// WithStackSize sets the levels of nested subroutines, see SetStackSize.
+func WithStackSize(size int) Option {
+	return func(c *Chip8) error {
+		return c.SetStackSize(size)
+	}
+}
+
+// WithTraceWriter sets where executed instructions are traced to, see SetTraceWriter.
+func WithTraceWriter(w io.Writer) Option {
+	return func(c *Chip8) error {
+		c.SetTraceWriter(w)
+		return nil
+	}
+}
+
+// WithMemoryOverrunPolicy sets what the instructions do past the end of ram, see SetMemoryOverrunPolicy.
+func WithMemoryOverrunPolicy(policy MemoryOverrunPolicy) Option {
+	return func(c *Chip8) error {
+		c.SetMemoryOverrunPolicy(policy)
+		return nil
+	}
+}
+
+// WithStrictMode sets whether unknown opcodes halt the machine, see SetStrictMode.
+func WithStrictMode(strict bool) Option {
+	return func(c *Chip8) error {
+		c.SetStrictMode(strict)
+		return nil
+	}
+}
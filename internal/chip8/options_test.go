@@ -0,0 +1,61 @@
+package chip8
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChip8WithOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies the options", func(t *testing.T) {
+		var trace bytes.Buffer
+		player := &fakeSoundPlayer{}
+		quirks := Quirks{ShiftUsesVY: true}
+
+		chip8, err := NewChip8WithOptions(
+			WithTPS(600),
+			WithQuirks(quirks),
+			WithRandSeed(42),
+			WithSoundPlayer(player),
+			WithStackSize(32),
+			WithTraceWriter(&trace),
+			WithMemoryOverrunPolicy(OverrunClamp),
+			WithStrictMode(true),
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, 600, chip8.GetTPS())
+		require.Equal(t, quirks, chip8.Quirks())
+		require.Equal(t, 32, chip8.StackSize())
+		require.True(t, chip8.StrictMode())
+		require.Equal(t, OverrunClamp, chip8.overrunPolicy)
+		require.Same(t, player, chip8.soundPlayer)
+
+		seeded := NewChip8()
+		seeded.SetRandSeed(42)
+		require.Equal(t, seeded.randomByte(), chip8.randomByte())
+
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x60, 0x05, // 0x200: v[0] = 5
+			},
+		})
+		chip8.Emulate()
+		require.NotEmpty(t, trace.String())
+	})
+
+	t.Run("no options", func(t *testing.T) {
+		chip8, err := NewChip8WithOptions()
+		require.NoError(t, err)
+		require.Equal(t, NewChip8().Snapshot(), chip8.Snapshot())
+		require.Equal(t, defaultTPS, chip8.GetTPS())
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		_, err := NewChip8WithOptions(WithTPS(600), WithStackSize(0))
+		require.ErrorIs(t, err, ErrInvalidStackSize)
+	})
+}
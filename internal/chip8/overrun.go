@@ -0,0 +1,78 @@
+package chip8
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrMemoryOverrun = errors.New("memory access is out of ram")
+
+// MemoryOverrunPolicy selects what the instructions that access ram starting at I do
+// when the bytes don't fit in ram: FX55/FX65 copying V0 to VX, FX33 storing the BCD digits,
+// and DXYN reading the sprite. I can point past the end of ram after FX1E.
+type MemoryOverrunPolicy int
+
+const (
+	// OverrunHalt halts the machine with ErrMemoryOverrun before any byte is accessed.
+	OverrunHalt MemoryOverrunPolicy = iota
+	// OverrunWrap continues accessing from the start of ram.
+	OverrunWrap
+	// OverrunClamp accesses only the bytes that fit, e.g. the registers past the end are left unmodified
+	// and the sprite rows past the end aren't drawn.
+	OverrunClamp
+)
+
+func (p MemoryOverrunPolicy) String() string {
+	switch p {
+	case OverrunHalt:
+		return "halt"
+	case OverrunWrap:
+		return "wrap"
+	case OverrunClamp:
+		return "clamp"
+	}
+	return ""
+}
+
+// SetMemoryOverrunPolicy sets what FX55/FX65, FX33, and DXYN do when they run past the end of ram. OverrunHalt is default.
+func (c *Chip8) SetMemoryOverrunPolicy(policy MemoryOverrunPolicy) {
+	c.overrunPolicy = policy
+}
+
+// the most bytes an instruction accesses starting at I, the 16x16 sprite of DXY0
+const maxRAMRange = 32
+
+// ramRangeAddrs returns the ram addresses of n bytes starting at I,
+// and the number of the bytes to access according to the overrun policy.
+func (c Chip8) ramRangeAddrs(n int) ([maxRAMRange]uint16, int, error) {
+	var addrs [maxRAMRange]uint16
+
+	for i := 0; i < n; i++ {
+		addr := int(c.regI) + i
+		if addr < ramSizeBytes {
+			addrs[i] = uint16(addr)
+			continue
+		}
+
+		switch c.overrunPolicy {
+		case OverrunWrap:
+			addrs[i] = uint16(addr % ramSizeBytes)
+		case OverrunClamp:
+			return addrs, i, nil
+		default:
+			return addrs, 0, fmt.Errorf("%d bytes at %04X: %w", n, c.regI, ErrMemoryOverrun)
+		}
+	}
+	return addrs, n, nil
+}
+
+// halt stops the machine for good, the error is reported by Err.
+func (c *Chip8) halt(err error) {
+	c.state = StateHalted
+	c.err = err
+}
+
+// Err returns the error that halted the machine or nil.
+func (c Chip8) Err() error {
+	return c.err
+}
@@ -0,0 +1,195 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_MemoryOverrunPolicy(t *testing.T) {
+	t.Parallel()
+
+	// 6 of 16 registers fit from I to the end of ram
+	const regI = ramSizeBytes - 6
+
+	newChip8 := func(policy MemoryOverrunPolicy, opcode byte) Chip8 {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0xaf, 0xfa, // vI = 0xffa
+				0xff, opcode, // store/load V0-VF
+			},
+		})
+		chip8.SetMemoryOverrunPolicy(policy)
+
+		for i := range chip8.regsV {
+			chip8.regsV[i] = uint8(i + 1)
+		}
+		for i := regI; i < ramSizeBytes; i++ {
+			chip8.ram[i] = 0xa0 + uint8(i-regI)
+		}
+
+		chip8.step()
+		chip8.step()
+		return chip8
+	}
+
+	t.Run("FX55 halt", func(t *testing.T) {
+		chip8 := newChip8(OverrunHalt, 0x55)
+
+		require.Equal(t, StateHalted, chip8.GetState())
+		require.ErrorIs(t, chip8.Err(), ErrMemoryOverrun)
+		// nothing is stored
+		require.Equal(t, uint8(0xa0), chip8.ram[regI])
+		require.Equal(t, font[0], chip8.ram[0])
+
+		// the halted machine doesn't execute anymore
+		pc := chip8.pc
+		chip8.step()
+		require.Equal(t, pc, chip8.pc)
+	})
+
+	t.Run("FX55 wrap", func(t *testing.T) {
+		chip8 := newChip8(OverrunWrap, 0x55)
+
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.NoError(t, chip8.Err())
+		require.Equal(t, []byte{1, 2, 3, 4, 5, 6}, chip8.ram[regI:])
+		require.Equal(t, []byte{7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, chip8.ram[:10])
+	})
+
+	t.Run("FX55 clamp", func(t *testing.T) {
+		chip8 := newChip8(OverrunClamp, 0x55)
+
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.Equal(t, []byte{1, 2, 3, 4, 5, 6}, chip8.ram[regI:])
+		require.Equal(t, font[0], chip8.ram[0])
+	})
+
+	t.Run("FX65 halt", func(t *testing.T) {
+		chip8 := newChip8(OverrunHalt, 0x65)
+
+		require.Equal(t, StateHalted, chip8.GetState())
+		require.ErrorIs(t, chip8.Err(), ErrMemoryOverrun)
+		require.Equal(t, uint8(1), chip8.regsV[0])
+	})
+
+	t.Run("FX65 wrap", func(t *testing.T) {
+		chip8 := newChip8(OverrunWrap, 0x65)
+
+		require.Equal(t, []uint8{0xa0, 0xa1, 0xa2, 0xa3, 0xa4, 0xa5}, chip8.regsV[:6])
+		require.Equal(t, font[:10], chip8.regsV[6:])
+	})
+
+	t.Run("FX65 clamp", func(t *testing.T) {
+		chip8 := newChip8(OverrunClamp, 0x65)
+
+		require.Equal(t, []uint8{0xa0, 0xa1, 0xa2, 0xa3, 0xa4, 0xa5}, chip8.regsV[:6])
+		require.Equal(t, []uint8{7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, chip8.regsV[6:])
+	})
+}
+
+func TestChip8_MemoryOverrunPolicy_Sprite(t *testing.T) {
+	t.Parallel()
+
+	// 2 of 5 sprite rows fit from I to the end of ram
+	newChip8 := func(policy MemoryOverrunPolicy) Chip8 {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0xaf, 0xfe, // vI = 0xffe
+				0xd0, 0x05, // draw(0, 0, 5)
+			},
+		})
+		chip8.SetMemoryOverrunPolicy(policy)
+		chip8.ram[ramSizeBytes-2] = 0x80
+		chip8.ram[ramSizeBytes-1] = 0x40
+
+		chip8.step()
+		chip8.step()
+		return chip8
+	}
+
+	t.Run("halt", func(t *testing.T) {
+		chip8 := newChip8(OverrunHalt)
+
+		require.Equal(t, StateHalted, chip8.GetState())
+		require.ErrorIs(t, chip8.Err(), ErrMemoryOverrun)
+		require.Equal(t, [screenBufferSize]bool{}, chip8.screen, "nothing is drawn")
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		chip8 := newChip8(OverrunWrap)
+
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.True(t, chip8.ScreenPixelSetAt(0, 0))
+		require.True(t, chip8.ScreenPixelSetAt(1, 1))
+		// the rest of the rows are the top of the font sprite of 0
+		require.True(t, chip8.ScreenPixelSetAt(3, 2))
+		require.True(t, chip8.ScreenPixelSetAt(3, 4))
+	})
+
+	t.Run("clamp", func(t *testing.T) {
+		chip8 := newChip8(OverrunClamp)
+
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.True(t, chip8.ScreenPixelSetAt(0, 0))
+		require.True(t, chip8.ScreenPixelSetAt(1, 1))
+		for x := 0; x < 8; x++ {
+			require.False(t, chip8.ScreenPixelSetAt(x, 2), "rows past ram aren't drawn")
+		}
+	})
+}
+
+func TestChip8_MemoryOverrunPolicy_BCD(t *testing.T) {
+	t.Parallel()
+
+	// 1 of 3 digits fits, I is moved past the end of ram by FX1E
+	newChip8 := func(policy MemoryOverrunPolicy, offset uint8) Chip8 {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0xaf, 0xff, // vI = 0xfff
+				0x61, offset, // v[1] = offset
+				0xf1, 0x1e, // vI += v[1]
+				0x60, 0xfe, // v[0] = 254
+				0xf0, 0x33, // BCD(v[0])
+			},
+		})
+		chip8.SetMemoryOverrunPolicy(policy)
+
+		for i := 0; i < 5; i++ {
+			chip8.step()
+		}
+		return chip8
+	}
+
+	t.Run("halt", func(t *testing.T) {
+		chip8 := newChip8(OverrunHalt, 0)
+
+		require.Equal(t, StateHalted, chip8.GetState())
+		require.ErrorIs(t, chip8.Err(), ErrMemoryOverrun)
+		require.Equal(t, uint8(0), chip8.ram[ramSizeBytes-1])
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		chip8 := newChip8(OverrunWrap, 0)
+
+		require.Equal(t, uint8(2), chip8.ram[ramSizeBytes-1])
+		require.Equal(t, []byte{5, 4}, chip8.ram[:2])
+	})
+
+	t.Run("clamp", func(t *testing.T) {
+		chip8 := newChip8(OverrunClamp, 0)
+
+		require.Equal(t, uint8(2), chip8.ram[ramSizeBytes-1])
+		require.Equal(t, font[:2], chip8.ram[:2])
+	})
+
+	t.Run("I past the end of ram", func(t *testing.T) {
+		chip8 := newChip8(OverrunWrap, 0x10)
+
+		require.Equal(t, uint16(ramSizeBytes+0xf), chip8.regI)
+		require.Equal(t, []byte{2, 5, 4}, chip8.ram[0xf:0x12])
+	})
+}
@@ -0,0 +1,146 @@
+package chip8
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Quirks selects how the instructions that differ between interpreters behave.
+// The zero value is the modern behavior the machine has by default.
+type Quirks struct {
+	// 8XY6/8XYE shift VY and store the result in VX like the original COSMAC VIP interpreter,
+	// instead of shifting VX in place.
+	ShiftUsesVY bool
+	// FX55/FX65 increase I by X+1 like the original COSMAC VIP interpreter, instead of leaving it unchanged.
+	MemoryIncrementsI bool
+	// BNNN is read as BXNN and jumps to XNN plus VX like SUPER-CHIP, instead of NNN plus V0.
+	JumpUsesVX bool
+	// DXYN draws the part of a sprite that goes past the screen edges from the opposite edges.
+	// By default it's clipped: the pixels past the right edge and the rows past the bottom edge aren't drawn.
+	// The start position of a sprite is always wrapped.
+	SpriteWrapping bool
+	// DXYN waits for the vertical blank like the original COSMAC VIP interpreter,
+	// so at most one sprite is drawn per 60 Hz frame.
+	DisplayWait bool
+}
+
+var ErrUnknownQuirk = errors.New("unknown quirk")
+
+// quirkFields maps the identifiers of the quirks to their fields, in the order of the fields
+var quirkFields = []struct {
+	name  string
+	field func(q *Quirks) *bool
+}{
+	{"shift", func(q *Quirks) *bool { return &q.ShiftUsesVY }},
+	{"memory", func(q *Quirks) *bool { return &q.MemoryIncrementsI }},
+	{"jump", func(q *Quirks) *bool { return &q.JumpUsesVX }},
+	{"wrap", func(q *Quirks) *bool { return &q.SpriteWrapping }},
+	{"vblank", func(q *Quirks) *bool { return &q.DisplayWait }},
+}
+
+// quirkProfiles are the quirks of the interpreters games are commonly written for
+var quirkProfiles = map[string]Quirks{
+	// no quirks, the default behavior
+	"modern": {},
+	// the original COSMAC VIP interpreter
+	"vip": {ShiftUsesVY: true, MemoryIncrementsI: true, DisplayWait: true},
+	// SUPER-CHIP 1.1 on the HP48
+	"schip": {JumpUsesVX: true},
+}
+
+// QuirkNames returns the identifiers of the individual quirks accepted by ParseQuirks:
+// shift (ShiftUsesVY), memory (MemoryIncrementsI), jump (JumpUsesVX), wrap (SpriteWrapping)
+// and vblank (DisplayWait).
+func QuirkNames() []string {
+	names := make([]string, len(quirkFields))
+	for i, quirk := range quirkFields {
+		names[i] = quirk.name
+	}
+	return names
+}
+
+// QuirkProfileNames returns the sorted names of the quirk profiles accepted by ParseQuirks:
+// modern has no quirks, vip has the ones of the COSMAC VIP, schip the ones of SUPER-CHIP 1.1.
+func QuirkProfileNames() []string {
+	names := make([]string, 0, len(quirkProfiles))
+	for name := range quirkProfiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseQuirks parses comma separated quirk and profile identifiers, e.g. "vip,wrap".
+// The quirks of all of them are enabled. An empty string is no quirks.
+func ParseQuirks(s string) (Quirks, error) {
+	var quirks Quirks
+	if len(s) == 0 {
+		return quirks, nil
+	}
+
+parse:
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if profile, ok := quirkProfiles[name]; ok {
+			for _, quirk := range quirkFields {
+				*quirk.field(&quirks) = *quirk.field(&quirks) || *quirk.field(&profile)
+			}
+			continue
+		}
+		for _, quirk := range quirkFields {
+			if quirk.name == name {
+				*quirk.field(&quirks) = true
+				continue parse
+			}
+		}
+		return Quirks{}, fmt.Errorf("%w %s", ErrUnknownQuirk, name)
+	}
+	return quirks, nil
+}
+
+// SetQuirks sets how the instructions that differ between interpreters behave.
+func (c *Chip8) SetQuirks(quirks Quirks) {
+	c.quirks = quirks
+}
+
+// Quirks returns the quirks the machine runs with.
+func (c Chip8) Quirks() Quirks {
+	return c.quirks
+}
+
+// shiftSource returns the register shifted by 8XY6/8XYE.
+func (c Chip8) shiftSource(x, y uint8) uint8 {
+	if c.quirks.ShiftUsesVY {
+		return c.regsV[y]
+	}
+	return c.regsV[x]
+}
+
+// incrementMemoryIndex moves I past the registers stored or loaded by FX55/FX65 if the quirk is on.
+func (c *Chip8) incrementMemoryIndex(x uint8) {
+	if c.quirks.MemoryIncrementsI {
+		c.regI += uint16(x) + 1
+	}
+}
+
+// jumpOffset returns the register added to the address of BNNN.
+func (c Chip8) jumpOffset(x uint8) uint8 {
+	if c.quirks.JumpUsesVX {
+		return c.regsV[x]
+	}
+	return c.regsV[0]
+}
+
+// displayReady reports whether DXYN can draw now and counts the draw for the DisplayWait quirk.
+func (c *Chip8) displayReady() bool {
+	if !c.quirks.DisplayWait {
+		return true
+	}
+	if c.drewThisFrame {
+		return false
+	}
+	c.drewThisFrame = true
+	return true
+}
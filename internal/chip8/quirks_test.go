@@ -0,0 +1,209 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_QuirkShiftUsesVY(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opcode      byte
+		shiftUsesVY bool
+		expectedV0  uint8
+		expectedVF  uint8
+	}{
+		// v[0] = 0x11, v[1] = 0x82
+		{"8XY6 shifts VX", 0x06, false, 0x08, 1},
+		{"8XY6 shifts VY", 0x06, true, 0x41, 0},
+		{"8XYE shifts VX", 0x0e, false, 0x22, 0},
+		{"8XYE shifts VY", 0x0e, true, 0x04, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip8 := NewChip8()
+			chip8.SetQuirks(Quirks{ShiftUsesVY: tt.shiftUsesVY})
+			chip8.LoadRom(Rom{
+				Data: []byte{
+					0x60, 0x11, // v[0] = 0x11
+					0x61, 0x82, // v[1] = 0x82
+					0x80, 0x10 | tt.opcode, // shift into v[0]
+				},
+			})
+
+			for i := 0; i < 3; i++ {
+				chip8.step()
+			}
+
+			require.Equal(t, tt.expectedV0, chip8.regsV[0])
+			require.Equal(t, tt.expectedVF, chip8.regsV[0xf])
+			require.Equal(t, uint8(0x82), chip8.regsV[1], "VY is never changed")
+		})
+	}
+}
+
+func TestChip8_QuirkMemoryIncrementsI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		opcode            byte
+		memoryIncrementsI bool
+		expectedI         uint16
+	}{
+		{"FX55 leaves I", 0x55, false, 0x300},
+		{"FX55 increments I", 0x55, true, 0x303},
+		{"FX65 leaves I", 0x65, false, 0x300},
+		{"FX65 increments I", 0x65, true, 0x303},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip8 := NewChip8()
+			chip8.SetQuirks(Quirks{MemoryIncrementsI: tt.memoryIncrementsI})
+			chip8.LoadRom(Rom{
+				Data: []byte{
+					0xa3, 0x00, // vI = 0x300
+					0xf2, tt.opcode, // store or load v[0]..v[2]
+				},
+			})
+
+			chip8.step()
+			chip8.step()
+
+			require.Equal(t, tt.expectedI, chip8.regI)
+		})
+	}
+}
+
+func TestChip8_QuirkJumpUsesVX(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		jumpUsesVX bool
+		expectedPC uint16
+	}{
+		{"BNNN adds V0", false, 0x345 + 0x10},
+		{"BXNN adds VX", true, 0x345 + 0x20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip8 := NewChip8()
+			chip8.SetQuirks(Quirks{JumpUsesVX: tt.jumpUsesVX})
+			chip8.LoadRom(Rom{
+				Data: []byte{
+					0x60, 0x10, // v[0] = 0x10
+					0x63, 0x20, // v[3] = 0x20
+					0xb3, 0x45, // jump to 0x345 + v[0] or v[3]
+				},
+			})
+
+			for i := 0; i < 3; i++ {
+				chip8.step()
+			}
+
+			require.Equal(t, tt.expectedPC, chip8.pc)
+		})
+	}
+}
+
+func TestChip8_QuirkDisplayWait(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0xa0, 0x00, // 0x200: vI = font sprite of 0
+			0xd0, 0x15, // 0x202: draw(v[0], v[1], 5)
+			0x60, 0x08, // 0x204: v[0] = 8
+			0xd0, 0x15, // 0x206: draw(v[0], v[1], 5)
+			0x12, 0x08, // 0x208: jump to 0x208
+		},
+	}
+
+	t.Run("off", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.SetTPS(600)
+		chip8.LoadRom(rom)
+
+		for i := 0; i < 4; i++ {
+			chip8.step()
+		}
+		require.Equal(t, uint16(0x208), chip8.pc)
+		require.True(t, chip8.ScreenPixelSetAt(8, 0))
+	})
+
+	t.Run("on", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.SetTPS(600) // 10 instructions per frame
+		chip8.SetQuirks(Quirks{DisplayWait: true})
+		chip8.LoadRom(rom)
+
+		for i := 0; i < 4; i++ {
+			chip8.step()
+		}
+		require.Equal(t, uint16(0x206), chip8.pc, "the second draw waits for the next frame")
+		require.True(t, chip8.ScreenPixelSetAt(0, 0))
+		require.False(t, chip8.ScreenPixelSetAt(8, 0))
+
+		// the rest of the frame
+		for i := 4; i < 10; i++ {
+			chip8.step()
+		}
+		require.Equal(t, uint16(0x206), chip8.pc)
+		require.Equal(t, uint64(1), chip8.FrameCount())
+
+		chip8.step()
+		require.Equal(t, uint16(0x208), chip8.pc)
+		require.True(t, chip8.ScreenPixelSetAt(8, 0))
+	})
+}
+
+func TestQuirkNames(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"shift", "memory", "jump", "wrap", "vblank"}, QuirkNames())
+	require.Equal(t, []string{"modern", "schip", "vip"}, QuirkProfileNames())
+}
+
+func TestParseQuirks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		s        string
+		expected Quirks
+	}{
+		{"empty", "", Quirks{}},
+		{"quirk", "shift", Quirks{ShiftUsesVY: true}},
+		{"quirks", "jump, wrap,vblank", Quirks{JumpUsesVX: true, SpriteWrapping: true, DisplayWait: true}},
+		{"profile", "vip", Quirks{ShiftUsesVY: true, MemoryIncrementsI: true, DisplayWait: true}},
+		{"profile and quirk", "schip,wrap", Quirks{JumpUsesVX: true, SpriteWrapping: true}},
+		{"modern", "modern", Quirks{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quirks, err := ParseQuirks(tt.s)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, quirks)
+		})
+	}
+
+	t.Run("every name", func(t *testing.T) {
+		for _, name := range append(QuirkNames(), QuirkProfileNames()...) {
+			_, err := ParseQuirks(name)
+			require.NoError(t, err, name)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := ParseQuirks("shift,turbo")
+		require.ErrorIs(t, err, ErrUnknownQuirk)
+		require.ErrorContains(t, err, "turbo")
+	})
+}
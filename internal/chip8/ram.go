@@ -0,0 +1,33 @@
+package chip8
+
+import v2 "math/rand/v2"
+
+// SetRandomizeRAM fills the ram that isn't taken by the font and the rom with pseudo-random bytes
+// instead of zeros, like the garbage in the memory of real hardware.
+// The same seed gives the same bytes, so roms that read uninitialized memory behave reproducibly.
+func (c *Chip8) SetRandomizeRAM(seed uint64) {
+	c.randomizeRAM = true
+	c.ramSeed = seed
+	c.fillFreeRAM()
+}
+
+// fillFreeRAM overwrites the ram outside of the font and the rom according to the randomize option.
+func (c *Chip8) fillFreeRAM() {
+	var r *v2.Rand
+	if c.randomizeRAM {
+		r = v2.New(v2.NewPCG(c.ramSeed, c.ramSeed))
+	}
+
+	romEnd := entryPoint + len(c.rom.Data)
+	for i := range c.ram {
+		// the byte is generated for every address, so it doesn't depend on the rom size
+		var b uint8
+		if r != nil {
+			b = uint8(r.Uint32())
+		}
+		if i < len(font) || i >= entryPoint && i < romEnd {
+			continue
+		}
+		c.ram[i] = b
+	}
+}
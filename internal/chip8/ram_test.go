@@ -0,0 +1,52 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SetRandomizeRAM(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x01, // v[0] = 1
+		},
+	}
+	romEnd := entryPoint + len(rom.Data)
+
+	newChip8 := func(seed uint64) Chip8 {
+		chip8 := NewChip8()
+		chip8.SetRandomizeRAM(seed)
+		chip8.LoadRom(rom)
+		return chip8
+	}
+
+	chip8 := newChip8(42)
+
+	// the font and the rom are intact
+	require.Equal(t, font, chip8.ram[:len(font)])
+	require.Equal(t, rom.Data, chip8.ram[entryPoint:romEnd])
+
+	// the rest of ram isn't zeroed
+	require.NotEqual(t, make([]byte, entryPoint-len(font)), chip8.ram[len(font):entryPoint])
+	require.NotEqual(t, make([]byte, ramSizeBytes-romEnd), chip8.ram[romEnd:])
+
+	// the same seed gives the same ram, another seed gives another one
+	require.Equal(t, chip8.ram, newChip8(42).ram)
+	require.NotEqual(t, chip8.ram, newChip8(43).ram)
+
+	t.Run("randomized after load", func(t *testing.T) {
+		other := NewChip8()
+		other.LoadRom(rom)
+		other.SetRandomizeRAM(42)
+		require.Equal(t, chip8.ram, other.ram)
+	})
+
+	t.Run("zeroed by default", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		require.Equal(t, make([]byte, ramSizeBytes-romEnd), chip8.ram[romEnd:])
+	})
+}
@@ -0,0 +1,19 @@
+package chip8
+
+import (
+	v2 "math/rand/v2"
+)
+
+// SetRandSeed makes CXNN produce the same random numbers for the same seed, e.g. for tests and replays.
+// Without a seed the numbers come from the randomly seeded global source.
+func (c *Chip8) SetRandSeed(seed uint64) {
+	c.rand = v2.New(v2.NewPCG(seed, seed))
+}
+
+// randomByte returns the random number of CXNN.
+func (c *Chip8) randomByte() uint8 {
+	if c.rand != nil {
+		return uint8(c.rand.IntN(0x100))
+	}
+	return uint8(v2.IntN(0x100))
+}
@@ -0,0 +1,36 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SetRandSeed(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0xc0, 0xff, // 0x200: v[0] = rnd() & 0xff
+			0xc1, 0xff, // 0x202: v[1] = rnd() & 0xff
+			0xc2, 0xff, // 0x204: v[2] = rnd() & 0xff
+			0xc3, 0xff, // 0x206: v[3] = rnd() & 0xff
+			0xc4, 0x0f, // 0x208: v[4] = rnd() & 0x0f
+		},
+	}
+
+	run := func(seed uint64) [0x10]uint8 {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetRandSeed(seed)
+		for i := 0; i < len(rom.Data)/2; i++ {
+			chip8.step()
+		}
+		return chip8.regsV
+	}
+
+	regs := run(42)
+	require.Equal(t, regs, run(42), "the same seed gives the same numbers")
+	require.NotEqual(t, regs, run(43))
+	require.LessOrEqual(t, regs[4], uint8(0x0f), "the numbers are masked with NN")
+}
@@ -0,0 +1,19 @@
+package chip8
+
+// SetMaxIPS caps the number of instructions per second regardless of TPS,
+// e.g. to the 500-1000 instructions of the original COSMAC VIP. Zero or negative n removes the cap.
+// Run spaces the instructions to the capped rate, the renderer runs the capped rate per frame,
+// see InstructionRate. The timers still tick 60 times per second of the capped rate.
+func (c *Chip8) SetMaxIPS(n int) {
+	c.maxIPS = max(n, 0)
+}
+
+// InstructionRate returns how many instructions per second the machine should run at:
+// the tps, or the max ips if it's lower. Emulate doesn't wait, it's up to the caller
+// to run it at this rate, e.g. the renderer or Run.
+func (c Chip8) InstructionRate() int {
+	if c.maxIPS > 0 && c.maxIPS < c.tps {
+		return c.maxIPS
+	}
+	return c.tps
+}
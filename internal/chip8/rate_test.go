@@ -0,0 +1,98 @@
+package chip8
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SetMaxIPS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tps      int
+		maxIPS   int
+		expected int
+	}{
+		{"caps tps", 2000, 500, 500},
+		{"not limited", 2000, 0, 2000},
+		{"above tps", 100, 500, 100},
+		{"negative", 100, -1, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip8 := NewChip8()
+			chip8.SetTPS(tt.tps)
+			chip8.SetMaxIPS(tt.maxIPS)
+
+			require.Equal(t, tt.expected, chip8.InstructionRate())
+		})
+	}
+}
+
+func TestChip8_SetMaxIPS_Timers(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	chip8.LoadRom(Rom{
+		Data: []byte{
+			0x60, 0xff, // 0x200: v[0] = 0xff
+			0xf0, 0x15, // 0x202: delay timer = v[0]
+			0x12, 0x04, // 0x204: jump to 0x204
+		},
+	})
+	chip8.SetTPS(2000)
+	chip8.SetMaxIPS(500)
+	chip8.step()
+	chip8.step()
+	require.Equal(t, uint8(0xff), chip8.DelayTimer())
+	frames := chip8.FrameCount()
+
+	// a second of the emulated time at the capped rate
+	for i := 0; i < 500; i++ {
+		chip8.step()
+	}
+	require.Equal(t, uint64(FramesPerSecond), chip8.FrameCount()-frames)
+	require.InDelta(t, 0xff-FramesPerSecond, chip8.DelayTimer(), 1)
+}
+
+func TestChip8_SetTPS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tps      int
+		expected int
+	}{
+		{"default", 0, defaultTPS},
+		{"in range", 500, 500},
+		{"too slow", -10, minTPS},
+		{"too fast", 100_000, maxTPS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip8 := NewChip8()
+			if tt.tps != 0 {
+				chip8.SetTPS(tt.tps)
+			}
+			require.Equal(t, tt.expected, chip8.GetTPS())
+			require.Equal(t, tt.expected, chip8.InstructionRate())
+		})
+	}
+
+	t.Run("emulation doesn't wait for a tick", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{Data: []byte{0x12, 0x00}})
+		chip8.SetTPS(minTPS)
+		chip8.SetMaxIPS(minTPS)
+
+		start := time.Now()
+		for i := 0; i < 100; i++ {
+			chip8.Emulate()
+		}
+		require.Less(t, time.Since(start), time.Second, "100 ticks take 100 seconds at the tps")
+		require.Equal(t, uint64(100), chip8.InstructionCount())
+	})
+}
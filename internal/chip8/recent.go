@@ -0,0 +1,35 @@
+package chip8
+
+// the number of the last executed instructions kept for crash reports
+const recentInstructionsSize = 64
+
+// InstrRecord is an executed instruction.
+type InstrRecord struct {
+	PC     uint16
+	Opcode uint16
+}
+
+// recentInstructions is a ring of the last executed instructions.
+// It's cheap enough to be always on: recording is a single array write.
+type recentInstructions struct {
+	records [recentInstructionsSize]InstrRecord
+	// index of the next record to write
+	head int
+	size int
+}
+
+func (r *recentInstructions) push(record InstrRecord) {
+	r.records[r.head] = record
+	r.head = (r.head + 1) % recentInstructionsSize
+	r.size = min(r.size+1, recentInstructionsSize)
+}
+
+// RecentInstructions returns the last executed instructions from the oldest to the most recent one.
+func (c Chip8) RecentInstructions() []InstrRecord {
+	r := c.recent
+	records := make([]InstrRecord, 0, r.size)
+	for i := r.size; i > 0; i-- {
+		records = append(records, r.records[(r.head-i+recentInstructionsSize)%recentInstructionsSize])
+	}
+	return records
+}
@@ -0,0 +1,55 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_RecentInstructions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+		chip8 := NewChip8()
+		require.Empty(t, chip8.RecentInstructions())
+	})
+
+	t.Run("not full", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x60, 0x01, // 0x200: v[0] = 1
+				0x70, 0x01, // 0x202: v[0] += 1
+			},
+		})
+		chip8.step()
+		chip8.step()
+
+		require.Equal(t, []InstrRecord{
+			{PC: 0x200, Opcode: 0x6001},
+			{PC: 0x202, Opcode: 0x7001},
+		}, chip8.RecentInstructions())
+	})
+
+	t.Run("full", func(t *testing.T) {
+		// v[0] += 1 repeated more times than the ring holds
+		executed := recentInstructionsSize + 10
+		data := make([]byte, 0, executed*2)
+		for i := 0; i < executed; i++ {
+			data = append(data, 0x70, 0x01)
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{Data: data})
+		for i := 0; i < executed; i++ {
+			chip8.step()
+		}
+
+		records := chip8.RecentInstructions()
+		require.Len(t, records, recentInstructionsSize)
+		for i, record := range records {
+			require.Equal(t, uint16(entryPoint+(executed-recentInstructionsSize+i)*2), record.PC)
+			require.Equal(t, uint16(0x7001), record.Opcode)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package chip8
+
+import "slices"
+
+// Registers returns a copy of the V0..VF registers.
+func (c Chip8) Registers() [0x10]uint8 {
+	return c.regsV
+}
+
+// IndexRegister returns the I register.
+func (c Chip8) IndexRegister() uint16 {
+	return c.regI
+}
+
+// ProgramCounter returns the address of the next instruction.
+func (c Chip8) ProgramCounter() uint16 {
+	return c.pc
+}
+
+// StackPointer returns the number of the return addresses on the stack.
+func (c Chip8) StackPointer() uint8 {
+	return c.sp
+}
+
+// CallStack returns a copy of the return addresses on the stack, the one of the innermost call is last.
+func (c Chip8) CallStack() []uint16 {
+	return slices.Clone(c.stack[:c.sp])
+}
+
+// Timers returns the current values of the delay and sound timers.
+func (c Chip8) Timers() (delay, sound uint8) {
+	return c.delayTimer, c.soundTimer
+}
@@ -0,0 +1,75 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_Registers(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	chip8.SetTPS(maxTPS) // the timers don't tick in the few instructions
+	chip8.LoadRom(Rom{
+		Data: []byte{
+			0x60, 0x11, // 0x200: v[0] = 0x11
+			0x6f, 0x22, // 0x202: v[f] = 0x22
+			0xa3, 0x45, // 0x204: vI = 0x345
+			0xf0, 0x15, // 0x206: delay timer = v[0]
+			0xff, 0x18, // 0x208: sound timer = v[f]
+			0x22, 0x0e, // 0x20A: call 0x20E
+			0x00, 0x00, // 0x20C
+			0x12, 0x0e, // 0x20E: jump to 0x20E
+		},
+	})
+	for i := 0; i < 7; i++ {
+		chip8.step()
+	}
+
+	regs := chip8.Registers()
+	require.Equal(t, uint8(0x11), regs[0])
+	require.Equal(t, uint8(0x22), regs[0xf])
+	require.Equal(t, uint16(0x345), chip8.IndexRegister())
+	require.Equal(t, uint16(0x20e), chip8.ProgramCounter())
+	require.Equal(t, uint8(1), chip8.StackPointer())
+
+	delay, sound := chip8.Timers()
+	require.Equal(t, uint8(0x11), delay)
+	require.Equal(t, uint8(0x22), sound)
+
+	regs[0] = 0xff
+	require.Equal(t, uint8(0x11), chip8.regsV[0], "the registers are copied")
+}
+
+func TestChip8_CallStack(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	chip8.LoadRom(Rom{
+		Data: []byte{
+			0x22, 0x04, // 0x200: call 0x204
+			0x12, 0x02, // 0x202: jump to 0x202
+			0x22, 0x08, // 0x204: call 0x208
+			0x00, 0xee, // 0x206: return
+			0x00, 0xee, // 0x208: return
+		},
+	})
+	require.Empty(t, chip8.CallStack())
+
+	chip8.step()
+	require.Equal(t, []uint16{0x202}, chip8.CallStack())
+
+	chip8.step()
+	stack := chip8.CallStack()
+	require.Equal(t, []uint16{0x202, 0x206}, stack)
+
+	chip8.step()
+	require.Equal(t, []uint16{0x202}, chip8.CallStack())
+
+	chip8.step()
+	require.Empty(t, chip8.CallStack())
+
+	stack[0] = 0xfff
+	require.Equal(t, uint16(0x202), chip8.stack[0], "the stack is copied")
+}
@@ -0,0 +1,49 @@
+package chip8
+
+// Reset restarts the loaded rom, LoadRom does it for a new rom: the ram is reinitialized with the font and the rom,
+// and the registers, stack, timers, screen, and keypad are cleared.
+// The configuration, e.g. tps, quirks, policies, the sound player, and the flag registers, is kept.
+// A halted or exited machine runs again, a paused one stays paused.
+func (c *Chip8) Reset() {
+	c.ram = [ramSizeBytes]byte{}
+	copy(c.ram[:], c.font())
+	copy(c.ram[entryPoint:], c.rom.Data)
+	c.fillFreeRAM()
+
+	c.screen = [screenBufferSize]bool{}
+	c.hires = false
+	c.frame = [screenBufferSize]bool{}
+	c.frameHires = false
+	c.frameEnded = false
+	c.keyPad = [keyPadSize]bool{}
+	c.keyPressOrder = [keyPadSize]uint64{}
+	c.keyPresses = 0
+	c.regsV = [0x10]uint8{}
+	c.regI = 0
+	c.pc = entryPoint
+	clear(c.stack)
+	c.sp = 0
+	c.delayTimer = 0
+	c.setSoundTimer(0)
+	c.frameCount = 0
+	c.instructionCount = 0
+	c.frameTime = 0
+	c.drewThisFrame = false
+	c.waitingForKey = false
+	c.keyWaitPressed = false
+	c.watchHitOK = false
+	c.idleReported = false
+
+	if c.stopped() {
+		c.state = StateRunning
+	}
+	c.err = nil
+
+	// the history belongs to the previous run
+	if c.rewind != nil {
+		c.rewind.size = 0
+	}
+	c.rewindSaved = false
+	c.clearStepHistory()
+	c.recent = recentInstructions{}
+}
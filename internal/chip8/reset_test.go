@@ -0,0 +1,72 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_Reset(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x05, // 0x200: v[0] = 5
+			0xa0, 0x00, // 0x202: vI = 0x000
+			0xd0, 0x05, // 0x204: draw(v[0], v[0], 5)
+			0xa3, 0x00, // 0x206: vI = 0x300
+			0xf0, 0x55, // 0x208: store v[0] at 0x300
+			0xf0, 0x15, // 0x20A: delay timer = v[0]
+		},
+	}
+
+	chip8 := NewChip8()
+	chip8.LoadRom(rom)
+	chip8.SetTPS(600)
+	chip8.SetQuirks(Quirks{MemoryIncrementsI: true})
+	for i := 0; i < len(rom.Data)/2; i++ {
+		chip8.step()
+	}
+	require.NoError(t, chip8.SetKey(0x1, true))
+
+	chip8.Reset()
+
+	fresh := NewChip8()
+	fresh.LoadRom(rom)
+	require.Equal(t, fresh.ram, chip8.ram)
+	require.Equal(t, fresh.screen, chip8.screen)
+	require.Equal(t, fresh.regsV, chip8.regsV)
+	require.Equal(t, fresh.regI, chip8.regI)
+	require.Equal(t, fresh.pc, chip8.pc)
+	require.Equal(t, fresh.keyPad, chip8.keyPad)
+	require.Equal(t, uint8(0), chip8.DelayTimer())
+	require.Empty(t, chip8.RecentInstructions())
+	require.Equal(t, 600, chip8.GetTPS())
+	require.Equal(t, Quirks{MemoryIncrementsI: true}, chip8.Quirks())
+
+	// the rom runs again
+	for i := 0; i < len(rom.Data)/2; i++ {
+		chip8.step()
+	}
+	require.Equal(t, uint8(5), chip8.ram[0x300])
+	require.True(t, chip8.ScreenPixelSetAt(5, 5))
+
+	t.Run("exited", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{Data: []byte{0x00, 0xfd}})
+		chip8.step()
+
+		chip8.Reset()
+		require.Equal(t, StateRunning, chip8.GetState())
+	})
+
+	t.Run("halted", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.halt(ErrMemoryOverrun)
+
+		chip8.Reset()
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.NoError(t, chip8.Err())
+	})
+}
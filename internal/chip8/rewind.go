@@ -0,0 +1,69 @@
+package chip8
+
+// rewindBuffer is a ring of the most recent machine states.
+// The oldest state is overwritten when the ring is full.
+type rewindBuffer struct {
+	states []Snapshot
+	// index of the next state to write
+	head int
+	size int
+}
+
+func newRewindBuffer(depth int) *rewindBuffer {
+	return &rewindBuffer{
+		states: make([]Snapshot, depth),
+	}
+}
+
+func (b *rewindBuffer) push(s Snapshot) {
+	b.states[b.head] = s
+	b.head = (b.head + 1) % len(b.states)
+	b.size = min(b.size+1, len(b.states))
+}
+
+func (b *rewindBuffer) pop() (Snapshot, bool) {
+	if b.size == 0 {
+		return Snapshot{}, false
+	}
+	b.head = (b.head - 1 + len(b.states)) % len(b.states)
+	b.size--
+	return b.states[b.head], true
+}
+
+// EnableRewind keeps the states of the last seconds of gameplay to rewind them.
+// A state is saved at the start of every frame, 1/60 second of the emulated time.
+// Zero or negative seconds disables rewinding.
+func (c *Chip8) EnableRewind(seconds int) {
+	c.SetRewindDepth(seconds * FramesPerSecond)
+}
+
+// SetRewindDepth keeps the states at the start of the last depth frames to rewind them,
+// the memory use is bounded by depth snapshots. The states kept so far are dropped.
+// Zero or negative depth disables rewinding.
+func (c *Chip8) SetRewindDepth(depth int) {
+	if depth <= 0 {
+		c.rewind = nil
+		return
+	}
+	c.rewind = newRewindBuffer(depth)
+	c.rewindSaved = false
+}
+
+// Rewind restores the state at the start of the last executed frame,
+// the instructions of the frame are undone at once. The run state is restored too,
+// so rewinding a halted machine resumes it from before the crash.
+// It returns false if there is nothing to rewind.
+func (c *Chip8) Rewind() bool {
+	if c.rewind == nil {
+		return false
+	}
+
+	s, ok := c.rewind.pop()
+	if !ok {
+		return false
+	}
+	c.Restore(s)
+	// the restored frame is saved again when it runs
+	c.rewindSaved = false
+	return true
+}
@@ -0,0 +1,164 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_Rewind(t *testing.T) {
+	t.Parallel()
+
+	t.Run("restores earlier states", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x11, // v[0] = 0x11
+				0x61, 0x22, // v[1] = 0x22
+				0xa3, 0x00, // vI = 0x300
+				0x00, 0xe0, // clear screen
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetTPS(2 * FramesPerSecond)
+		chip8.EnableRewind(1)
+		chip8.screen[0] = true
+
+		// 2 instructions per frame, a state per frame
+		var states []Snapshot
+		for i := 0; i < 4; i++ {
+			if i%2 == 0 {
+				states = append(states, chip8.Snapshot())
+			}
+			chip8.Emulate()
+		}
+		require.False(t, chip8.screen[0])
+
+		for i := len(states) - 1; i >= 0; i-- {
+			require.True(t, chip8.Rewind())
+			require.Equal(t, states[i], chip8.Snapshot())
+		}
+
+		require.False(t, chip8.Rewind(), "nothing to rewind")
+		require.True(t, chip8.screen[0])
+		require.Equal(t, uint16(entryPoint), chip8.pc)
+	})
+
+	t.Run("bounded by depth", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x70, 0x01, // 0x200: v[0] += 1
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetTPS(4 * FramesPerSecond)
+		chip8.SetRewindDepth(3)
+
+		// 10 frames of 4 instructions
+		for i := 0; i < 10*4; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, uint64(10), chip8.FrameCount())
+
+		rewinds := 0
+		for chip8.Rewind() {
+			rewinds++
+		}
+		require.Equal(t, 3, rewinds)
+		require.Equal(t, uint8(14), chip8.regsV[0], "v[0] before the last 3 of 10 frames")
+		require.Equal(t, uint16(0x200), chip8.pc)
+	})
+
+	t.Run("mid frame", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x70, 0x01, // 0x200: v[0] += 1
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetTPS(4 * FramesPerSecond)
+		chip8.SetRewindDepth(3)
+
+		// a frame and a half
+		for i := 0; i < 6; i++ {
+			chip8.Emulate()
+		}
+
+		// back to the start of the current frame, then of the previous one
+		require.True(t, chip8.Rewind())
+		require.Equal(t, uint8(2), chip8.regsV[0])
+		require.True(t, chip8.Rewind())
+		require.Equal(t, uint8(0), chip8.regsV[0])
+		require.False(t, chip8.Rewind())
+	})
+
+	t.Run("the last seconds of the emulated time", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x70, 0x01, // 0x200: v[0] += 1
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetTPS(600)
+		chip8.EnableRewind(2)
+
+		// 3 seconds at 600 tps
+		for i := 0; i < 3*600; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, uint64(3*FramesPerSecond), chip8.FrameCount())
+
+		rewinds := 0
+		for chip8.Rewind() {
+			rewinds++
+		}
+		require.Equal(t, 2*FramesPerSecond, rewinds)
+		require.Equal(t, uint8(600/2%256), chip8.regsV[0], "v[0] after the first second")
+		require.Equal(t, uint16(0x200), chip8.pc)
+	})
+
+	t.Run("out of a halt", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x60, 0x11, // 0x200: v[0] = 0x11
+				0x00, 0xee, // 0x202: return with the empty stack
+			},
+		}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.EnableRewind(1)
+
+		chip8.Emulate()
+		require.ErrorIs(t, chip8.Emulate(), ErrStackUnderflow)
+		require.Equal(t, StateHalted, chip8.GetState())
+
+		require.True(t, chip8.Rewind())
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.NoError(t, chip8.Err())
+		require.Equal(t, uint16(0x202), chip8.pc)
+
+		// the machine runs on and crashes again
+		require.ErrorIs(t, chip8.Emulate(), ErrStackUnderflow)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{Data: []byte{0x60, 0x11}})
+		chip8.EnableRewind(0)
+
+		chip8.Emulate()
+
+		require.False(t, chip8.Rewind())
+	})
+}
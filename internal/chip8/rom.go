@@ -1,30 +1,74 @@
 package chip8
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"path"
+	"strings"
 )
 
+// the magic bytes and the deflate method of gzip streams.
+// A plain rom may start with 1F8B too, it's a jump to 0xF8B.
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
 type Rom struct {
 	Name string
 	Data []byte
 }
 
 func NewRomFromFile(romPath string) (Rom, error) {
-	data, err := os.ReadFile(romPath)
+	f, err := os.Open(romPath)
 	if err != nil {
 		return Rom{}, fmt.Errorf("read data from rom file %s: %w", romPath, err)
 	}
+	defer f.Close()
+
+	return NewRomFromReader(path.Base(romPath), f)
+}
+
+// NewRomFromReader reads the rom of the name to the end of r, e.g. from an embedded asset or an archive.
+// A gzip-compressed rom is decompressed and the .gz extension is dropped from the name.
+// A rom that doesn't fit in ram after decompression is rejected with ErrRomTooLarge,
+// at most one byte past the limit is read.
+func NewRomFromReader(name string, r io.Reader) (Rom, error) {
+	br := bufio.NewReader(r)
+	r = br
+	if magic, _ := br.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return Rom{}, fmt.Errorf("decompress rom %s: %w", name, err)
+		}
+		defer zr.Close()
+
+		r = zr
+		name = strings.TrimSuffix(name, ".gz")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, romMaxSizeBytes+1))
+	if err != nil {
+		return Rom{}, fmt.Errorf("read data of rom %s: %w", name, err)
+	}
 
 	if len(data) > romMaxSizeBytes {
-		return Rom{}, fmt.Errorf("rom file %s is too large. actual size is %d bytes, max size is %d bytes",
-			romPath, len(data), romMaxSizeBytes,
+		return Rom{}, fmt.Errorf("rom %s is larger than the max size of %d bytes: %w",
+			name, romMaxSizeBytes, ErrRomTooLarge,
 		)
 	}
 
 	return Rom{
-		Name: path.Base(romPath),
+		Name: name,
 		Data: data,
 	}, nil
 }
+
+// Hash returns the hex encoded sha1 of the rom data.
+func (r Rom) Hash() string {
+	sum := sha1.Sum(r.Data)
+	return hex.EncodeToString(sum[:])
+}
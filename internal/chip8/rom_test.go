@@ -0,0 +1,89 @@
+package chip8
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRomFromReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads the rom", func(t *testing.T) {
+		data := []byte{
+			0x00, 0xe0, // clear screen
+			0x12, 0x02, // jump to 0x202
+		}
+
+		rom, err := NewRomFromReader("loop.ch8", bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, "loop.ch8", rom.Name)
+		require.Equal(t, data, rom.Data)
+	})
+
+	t.Run("the largest rom", func(t *testing.T) {
+		rom, err := NewRomFromReader("big.ch8", bytes.NewReader(make([]byte, romMaxSizeBytes)))
+		require.NoError(t, err)
+		require.Len(t, rom.Data, romMaxSizeBytes)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := NewRomFromReader("huge.ch8", bytes.NewReader(make([]byte, romMaxSizeBytes+1)))
+		require.ErrorIs(t, err, ErrRomTooLarge)
+	})
+}
+
+func TestNewRomFromReader_Gzip(t *testing.T) {
+	t.Parallel()
+
+	gzipped := func(t *testing.T, data []byte) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, err := zw.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+
+	t.Run("same data as the plain rom", func(t *testing.T) {
+		plain, err := NewRomFromFile("../../roms/IBM_Logo.ch8")
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "IBM_Logo.ch8.gz")
+		require.NoError(t, os.WriteFile(path, gzipped(t, plain.Data), 0o644))
+
+		rom, err := NewRomFromFile(path)
+		require.NoError(t, err)
+		require.Equal(t, plain.Data, rom.Data)
+		require.Equal(t, "IBM_Logo.ch8", rom.Name)
+	})
+
+	t.Run("the limit applies to the decompressed size", func(t *testing.T) {
+		// zeros compress well below the limit
+		data := gzipped(t, make([]byte, romMaxSizeBytes+1))
+		require.Less(t, len(data), romMaxSizeBytes)
+
+		_, err := NewRomFromReader("zeros.ch8.gz", bytes.NewReader(data))
+		require.ErrorIs(t, err, ErrRomTooLarge)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		_, err := NewRomFromReader("bad.ch8.gz", bytes.NewReader([]byte{0x1f, 0x8b, 0x08, 0x00}))
+		require.Error(t, err)
+	})
+
+	t.Run("plain rom that starts like gzip", func(t *testing.T) {
+		data := []byte{
+			0x1f, 0x8b, // jump to 0xF8B
+			0x00, 0xe0, // clear screen
+		}
+
+		rom, err := NewRomFromReader("jump.ch8", bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, data, rom.Data)
+	})
+}
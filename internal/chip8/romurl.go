@@ -0,0 +1,52 @@
+package chip8
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+// the download of a rom from a URL is canceled after the timeout
+const romDownloadTimeout = 30 * time.Second
+
+// NewRomFromURL downloads the rom with an HTTP GET, see NewRomFromURLContext.
+// The download is canceled if it takes longer than 30 seconds.
+func NewRomFromURL(romURL string) (Rom, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), romDownloadTimeout)
+	defer cancel()
+
+	return NewRomFromURLContext(ctx, romURL)
+}
+
+// NewRomFromURLContext downloads the rom with an HTTP GET, the name of the rom is the last element of the URL path.
+// The response must be 200 OK. A rom that doesn't fit in ram is rejected with ErrRomTooLarge
+// without reading the rest of the body.
+func NewRomFromURLContext(ctx context.Context, romURL string) (Rom, error) {
+	u, err := url.Parse(romURL)
+	if err != nil {
+		return Rom{}, fmt.Errorf("parse rom url %s: %w", romURL, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Rom{}, fmt.Errorf("create request of rom url %s: %w", romURL, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Rom{}, fmt.Errorf("download rom from %s: %w", romURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Rom{}, fmt.Errorf("download rom from %s: unexpected status %s", romURL, resp.Status)
+	}
+
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		name = u.Host
+	}
+	return NewRomFromReader(name, resp.Body)
+}
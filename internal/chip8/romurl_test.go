@@ -0,0 +1,58 @@
+package chip8
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRomFromURL(t *testing.T) {
+	t.Parallel()
+
+	data := []byte{
+		0x00, 0xe0, // clear screen
+		0x12, 0x02, // jump to 0x202
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/roms/loop.ch8", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(data)
+	})
+	mux.HandleFunc("/roms/huge.ch8", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(make([]byte, 2*romMaxSizeBytes))
+	})
+	mux.HandleFunc("/roms/slow.ch8", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("downloads the rom", func(t *testing.T) {
+		rom, err := NewRomFromURL(server.URL + "/roms/loop.ch8")
+		require.NoError(t, err)
+		require.Equal(t, "loop.ch8", rom.Name)
+		require.Equal(t, data, rom.Data)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := NewRomFromURL(server.URL + "/roms/missing.ch8")
+		require.ErrorContains(t, err, "404 Not Found")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := NewRomFromURL(server.URL + "/roms/huge.ch8")
+		require.ErrorIs(t, err, ErrRomTooLarge)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := NewRomFromURLContext(ctx, server.URL+"/roms/slow.ch8")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,45 @@
+package chip8
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Run executes the instructions at the InstructionRate until the context is done,
+// an instruction per tick of the clock, see SetClock. It's what enforces the max ips.
+// It blocks, the machine is run in the background by calling it in a goroutine, e.g. to run more
+// instructions than the renderer draws frames. The tps may be changed while it runs.
+// Run holds mu while it reads or runs the machine, everything else must be called with mu locked too.
+// The lock is the caller's, so the machine can still be copied.
+// Run returns the error of the context.
+func (c *Chip8) Run(ctx context.Context, mu sync.Locker) error {
+	mu.Lock()
+	interval := c.runInterval()
+	ticker := c.clock.NewTicker(interval)
+	mu.Unlock()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C():
+		}
+
+		mu.Lock()
+		c.Emulate()
+		next := c.runInterval()
+		mu.Unlock()
+
+		if next != interval {
+			interval = next
+			ticker.Reset(interval)
+		}
+	}
+}
+
+// runInterval returns the time between the instructions executed by Run.
+func (c Chip8) runInterval() time.Duration {
+	return time.Second / time.Duration(c.InstructionRate())
+}
@@ -0,0 +1,113 @@
+package chip8
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runFake runs the machine in the background on a fake clock.
+// stop cancels the run and waits until it returns.
+func runFake(t *testing.T, chip8 *Chip8) (clock *fakeClock, ticker *fakeTicker, mu *sync.Mutex, stop func()) {
+	clock = newFakeClock()
+	chip8.SetClock(clock)
+	mu = &sync.Mutex{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- chip8.Run(ctx, mu)
+	}()
+
+	ticker = <-clock.created
+	stop = func() {
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+		require.True(t, ticker.stopped)
+	}
+	return clock, ticker, mu, stop
+}
+
+// advance moves the clock forward 1ms at a time
+func advance(clock *fakeClock, d time.Duration) {
+	for elapsed := time.Duration(0); elapsed < d; elapsed += time.Millisecond {
+		clock.Advance(time.Millisecond)
+	}
+}
+
+func TestChip8_Run(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x70, 0x01, // 0x200: v[0] += 1
+			0x12, 0x00, // 0x202: jump to 0x200
+		},
+	}
+
+	t.Run("an instruction per tick", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.SetTPS(500)
+		chip8.LoadRom(rom)
+		clock, ticker, mu, stop := runFake(t, &chip8)
+		require.Equal(t, 2*time.Millisecond, ticker.interval)
+
+		// the machine can be read while it runs, the last received tick may still be running
+		advance(clock, 100*time.Millisecond)
+		mu.Lock()
+		running := chip8.InstructionCount()
+		mu.Unlock()
+		require.GreaterOrEqual(t, running, uint64(49))
+
+		advance(clock, 100*time.Millisecond)
+		stop()
+		require.Equal(t, uint64(100), chip8.InstructionCount())
+	})
+
+	t.Run("the tps changes while it runs", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.SetTPS(1000)
+		chip8.LoadRom(rom)
+		clock, ticker, mu, stop := runFake(t, &chip8)
+
+		mu.Lock()
+		chip8.SetTPS(500)
+		mu.Unlock()
+
+		// the ticker is reset after the next instruction
+		clock.Advance(time.Millisecond)
+		require.Equal(t, 2*time.Millisecond, <-ticker.resets)
+
+		advance(clock, 20*time.Millisecond)
+		stop()
+		require.Equal(t, uint64(1+10), chip8.InstructionCount())
+	})
+}
+
+func TestChip8_Run_MaxIPS(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	chip8.SetTPS(2000)
+	chip8.SetMaxIPS(100)
+	chip8.LoadRom(Rom{
+		Data: []byte{
+			0x70, 0x01, // 0x200: v[0] += 1
+			0x12, 0x00, // 0x202: jump to 0x200
+		},
+	})
+	clock, ticker, _, stop := runFake(t, &chip8)
+	require.Equal(t, 10*time.Millisecond, ticker.interval)
+
+	// 30 instructions in 300ms at the cap instead of 600 at the tps
+	advance(clock, 300*time.Millisecond)
+	stop()
+	count := chip8.InstructionCount()
+	require.Equal(t, uint64(30), count)
+
+	// the timers run at 60 Hz of the capped rate: 60 frames per 100 instructions
+	require.Equal(t, count*FramesPerSecond/100, chip8.FrameCount())
+}
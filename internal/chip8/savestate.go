@@ -0,0 +1,144 @@
+package chip8
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrInvalidState = errors.New("state doesn't fit the machine")
+
+// stateFile is the JSON layout of a snapshot. The ram and the screen are the bulk of it,
+// they are kept as byte slices to be encoded as base64 strings instead of arrays of numbers.
+type stateFile struct {
+	RAM []byte `json:"ram"`
+	// a pixel per byte, 0 or 1
+	Screen []byte `json:"screen"`
+	Hires  bool   `json:"hires"`
+	KeyPad []bool `json:"keypad"`
+
+	RegsV []byte `json:"v"`
+	RegI  uint16 `json:"i"`
+	PC    uint16 `json:"pc"`
+
+	Stack []uint16 `json:"stack"`
+	SP    uint8    `json:"sp"`
+
+	DelayTimer uint8 `json:"delay_timer"`
+	SoundTimer uint8 `json:"sound_timer"`
+
+	State State `json:"state"`
+	// the message of the error that halted the machine, empty if it isn't halted
+	Err string `json:"error,omitempty"`
+
+	WaitingForKey  bool     `json:"waiting_for_key"`
+	KeyWaitKey     uint8    `json:"key_wait_key"`
+	KeyWaitPressed bool     `json:"key_wait_pressed"`
+	KeyPressOrder  []uint64 `json:"key_press_order"`
+	KeyPresses     uint64   `json:"key_presses"`
+
+	FrameCount       uint64 `json:"frames"`
+	InstructionCount uint64 `json:"instructions"`
+	FrameTime        int    `json:"frame_time"`
+	DrewThisFrame    bool   `json:"drew_this_frame"`
+}
+
+// SaveState writes the current state of the machine as JSON to be loaded later with LoadState.
+func (c Chip8) SaveState(w io.Writer) error {
+	s := c.Snapshot()
+	f := stateFile{
+		RAM:    s.RAM[:],
+		Screen: make([]byte, len(s.Screen)),
+		Hires:  s.Hires,
+		KeyPad: s.KeyPad[:],
+
+		RegsV: s.RegsV[:],
+		RegI:  s.RegI,
+		PC:    s.PC,
+
+		Stack: s.Stack,
+		SP:    s.SP,
+
+		DelayTimer: s.DelayTimer,
+		SoundTimer: s.SoundTimer,
+
+		State: s.State,
+
+		WaitingForKey:  s.WaitingForKey,
+		KeyWaitKey:     s.KeyWaitKey,
+		KeyWaitPressed: s.KeyWaitPressed,
+		KeyPressOrder:  s.KeyPressOrder[:],
+		KeyPresses:     s.KeyPresses,
+
+		FrameCount:       s.FrameCount,
+		InstructionCount: s.InstructionCount,
+		FrameTime:        s.FrameTime,
+		DrewThisFrame:    s.DrewThisFrame,
+	}
+	if s.Err != nil {
+		f.Err = s.Err.Error()
+	}
+	for i, on := range s.Screen {
+		if on {
+			f.Screen[i] = 1
+		}
+	}
+
+	if err := json.NewEncoder(w).Encode(f); err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	return nil
+}
+
+// LoadState restores the state of the machine written by SaveState.
+// The machine isn't changed if the state can't be read or was saved with another stack size.
+func (c *Chip8) LoadState(r io.Reader) error {
+	var f stateFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return fmt.Errorf("decode state: %w", err)
+	}
+
+	var s Snapshot
+	if len(f.RAM) != len(s.RAM) || len(f.Screen) != len(s.Screen) || len(f.KeyPad) != len(s.KeyPad) ||
+		len(f.RegsV) != len(s.RegsV) || len(f.Stack) != len(c.stack) || int(f.SP) > len(f.Stack) ||
+		(f.KeyPressOrder != nil && len(f.KeyPressOrder) != len(s.KeyPressOrder)) {
+		return ErrInvalidState
+	}
+
+	copy(s.RAM[:], f.RAM)
+	for i, pixel := range f.Screen {
+		s.Screen[i] = pixel != 0
+	}
+	s.Hires = f.Hires
+	copy(s.KeyPad[:], f.KeyPad)
+
+	copy(s.RegsV[:], f.RegsV)
+	s.RegI = f.RegI
+	s.PC = f.PC
+
+	s.Stack = f.Stack
+	s.SP = f.SP
+
+	s.DelayTimer = f.DelayTimer
+	s.SoundTimer = f.SoundTimer
+
+	s.State = f.State
+	if len(f.Err) > 0 {
+		s.Err = errors.New(f.Err)
+	}
+
+	s.WaitingForKey = f.WaitingForKey
+	s.KeyWaitKey = f.KeyWaitKey
+	s.KeyWaitPressed = f.KeyWaitPressed
+	copy(s.KeyPressOrder[:], f.KeyPressOrder)
+	s.KeyPresses = f.KeyPresses
+
+	s.FrameCount = f.FrameCount
+	s.InstructionCount = f.InstructionCount
+	s.FrameTime = f.FrameTime
+	s.DrewThisFrame = f.DrewThisFrame
+
+	c.Restore(s)
+	return nil
+}
@@ -0,0 +1,74 @@
+package chip8
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SaveState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xff, // 0x200: high resolution
+				0x60, 0x05, // 0x202: v[0] = 5
+				0xa0, 0x00, // 0x204: vI = font sprite of 0
+				0xd0, 0x05, // 0x206: draw(v[0], v[0], 5)
+				0xf0, 0x15, // 0x208: delay timer = v[0]
+				0xf0, 0x18, // 0x20A: sound timer = v[0]
+				0x22, 0x0e, // 0x20C: call 0x20E
+				0x12, 0x0e, // 0x20E: jump to 0x20E
+			},
+		})
+		for i := 0; i < 7; i++ {
+			chip8.step()
+		}
+		require.NoError(t, chip8.SetKey(0x3, true))
+
+		var buf bytes.Buffer
+		require.NoError(t, chip8.SaveState(&buf))
+
+		loaded := NewChip8()
+		require.NoError(t, loaded.LoadState(&buf))
+		require.Equal(t, chip8.Snapshot(), loaded.Snapshot())
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		chip8 := NewChip8()
+
+		var buf bytes.Buffer
+		require.NoError(t, chip8.SaveState(&buf))
+
+		// base64 of the ram and the screen
+		require.Less(t, buf.Len(), 4*(ramSizeBytes+screenBufferSize)/3+1024)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		chip8 := NewChip8()
+		before := chip8.Snapshot()
+
+		require.Error(t, chip8.LoadState(strings.NewReader("{")))
+		require.ErrorIs(t, chip8.LoadState(strings.NewReader(`{"ram":"AAAA"}`)), ErrInvalidState)
+		require.Equal(t, before, chip8.Snapshot())
+	})
+
+	t.Run("another stack size", func(t *testing.T) {
+		chip8 := NewChip8()
+		require.NoError(t, chip8.SetStackSize(32))
+
+		var buf bytes.Buffer
+		require.NoError(t, chip8.SaveState(&buf))
+
+		loaded := NewChip8()
+		require.ErrorIs(t, loaded.LoadState(bytes.NewReader(buf.Bytes())), ErrInvalidState)
+
+		require.NoError(t, loaded.SetStackSize(32))
+		require.NoError(t, loaded.LoadState(&buf))
+		require.Equal(t, chip8.Snapshot(), loaded.Snapshot())
+	})
+}
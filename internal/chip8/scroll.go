@@ -0,0 +1,37 @@
+package chip8
+
+// The screen is a single plane. The XO-CHIP plane selection FN01 is an unknown opcode,
+// so the scroll opcodes always move the whole screen, like XO-CHIP does with only the first plane selected.
+
+// scrollDown moves the screen down by n lines of the current resolution.
+// The lines scrolled off the bottom are lost, the vacated lines at the top are blank.
+func (c *Chip8) scrollDown(n int) {
+	width, height := c.ScreenSize()
+	for y := height - 1; y >= 0; y-- {
+		for x := 0; x < width; x++ {
+			c.screen[y*width+x] = y >= n && c.screen[(y-n)*width+x]
+		}
+	}
+}
+
+// scrollRight moves the screen right by n pixels of the current resolution.
+func (c *Chip8) scrollRight(n int) {
+	width, height := c.ScreenSize()
+	for y := 0; y < height; y++ {
+		row := c.screen[y*width : (y+1)*width]
+		for x := width - 1; x >= 0; x-- {
+			row[x] = x >= n && row[x-n]
+		}
+	}
+}
+
+// scrollLeft moves the screen left by n pixels of the current resolution.
+func (c *Chip8) scrollLeft(n int) {
+	width, height := c.ScreenSize()
+	for y := 0; y < height; y++ {
+		row := c.screen[y*width : (y+1)*width]
+		for x := 0; x < width; x++ {
+			row[x] = x+n < width && row[x+n]
+		}
+	}
+}
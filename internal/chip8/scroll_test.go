@@ -0,0 +1,131 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_Scroll(t *testing.T) {
+	t.Parallel()
+
+	// lit pixels in the corners of the screen
+	corners := func(chip8 *Chip8) {
+		width, height := chip8.ScreenSize()
+		chip8.screen[0] = true
+		chip8.screen[width-1] = true
+		chip8.screen[(height-1)*width] = true
+		chip8.screen[height*width-1] = true
+	}
+
+	t.Run("down", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xc3, // 0x200: scroll down by 3
+			},
+		})
+		corners(&chip8)
+
+		chip8.step()
+		require.True(t, chip8.ScreenPixelSetAt(0, 3))
+		require.True(t, chip8.ScreenPixelSetAt(63, 3))
+		require.False(t, chip8.ScreenPixelSetAt(0, 0), "the top lines are blank")
+		require.False(t, chip8.ScreenPixelSetAt(63, 0), "the top lines are blank")
+		require.False(t, chip8.ScreenPixelSetAt(0, 31), "the bottom line is scrolled off")
+		require.False(t, chip8.ScreenPixelSetAt(63, 31), "the bottom line is scrolled off")
+	})
+
+	t.Run("right", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xfb, // 0x200: scroll right by 4
+			},
+		})
+		corners(&chip8)
+
+		chip8.step()
+		require.True(t, chip8.ScreenPixelSetAt(4, 0))
+		require.True(t, chip8.ScreenPixelSetAt(4, 31))
+		require.False(t, chip8.ScreenPixelSetAt(0, 0), "the left columns are blank")
+		require.False(t, chip8.ScreenPixelSetAt(63, 0), "the right column is scrolled off")
+		require.False(t, chip8.ScreenPixelSetAt(0, 1), "doesn't wrap to the next line")
+	})
+
+	t.Run("left", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xfc, // 0x200: scroll left by 4
+			},
+		})
+		corners(&chip8)
+
+		chip8.step()
+		require.True(t, chip8.ScreenPixelSetAt(59, 0))
+		require.True(t, chip8.ScreenPixelSetAt(59, 31))
+		require.False(t, chip8.ScreenPixelSetAt(63, 0), "the right columns are blank")
+		require.False(t, chip8.ScreenPixelSetAt(0, 0), "the left column is scrolled off")
+		require.False(t, chip8.ScreenPixelSetAt(63, 30), "doesn't wrap to the previous line")
+	})
+
+	t.Run("high resolution", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xff, // 0x200: high resolution
+				0x00, 0xca, // 0x202: scroll down by 10
+				0x00, 0xfc, // 0x204: scroll left by 4
+			},
+		})
+		chip8.step()
+		corners(&chip8)
+
+		chip8.step()
+		require.True(t, chip8.ScreenPixelSetAt(0, 10))
+		require.True(t, chip8.ScreenPixelSetAt(127, 10))
+		require.False(t, chip8.ScreenPixelSetAt(127, 63))
+
+		chip8.step()
+		require.True(t, chip8.ScreenPixelSetAt(123, 10))
+		require.False(t, chip8.ScreenPixelSetAt(127, 10))
+		require.False(t, chip8.ScreenPixelSetAt(0, 10))
+	})
+
+	t.Run("a single plane", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0xa0, 0x00, // 0x200: vI = the font of 0
+				0xd0, 0x05, // 0x202: draw(v[0], v[0], 5)
+				0xf3, 0x01, // 0x204: select both planes, unknown
+				0x00, 0xc2, // 0x206: scroll down by 2
+			},
+		})
+		chip8.step()
+		chip8.step()
+		drawn := chip8.screen
+
+		chip8.step()
+		require.NoError(t, chip8.Err())
+		require.Equal(t, drawn, chip8.screen, "the plane selection is skipped")
+
+		// the screen moves as a whole, nothing is left behind
+		chip8.step()
+		expected := [screenBufferSize]bool{}
+		copy(expected[2*64:], drawn[:len(drawn)-2*64])
+		require.Equal(t, expected, chip8.screen)
+	})
+
+	t.Run("by the whole screen", func(t *testing.T) {
+		chip8 := NewChip8()
+		corners(&chip8)
+		chip8.scrollRight(100) // wider than the screen
+		require.Equal(t, [screenBufferSize]bool{}, chip8.screen)
+
+		corners(&chip8)
+		chip8.scrollDown(32)
+		require.Equal(t, [screenBufferSize]bool{}, chip8.screen)
+	})
+}
@@ -0,0 +1,57 @@
+package chip8
+
+import (
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+)
+
+// The self-test rom adds, subtracts, ands, and checks the carry flag,
+// then draws the decimal digits of the results with the font sprites:
+// 9 at (0, 0), 8 at (8, 0), and the carry 1 at (16, 0).
+//
+//go:embed selftest.ch8
+var selfTestRom []byte
+
+// screen hash of the finished self-test rom
+const selfTestScreenHash = "dd7233b9f6c6f78d81eee09cbbe2f4832214c272"
+
+// more than the self-test rom needs to reach its final self jump
+const selfTestMaxSteps = 1000
+
+// SelfTest runs the embedded self-test rom and compares the screen it draws to the expected one.
+// It returns an error if the screen differs.
+func SelfTest() error {
+	c := NewChip8()
+	if err := c.LoadRom(Rom{Name: "selftest", Data: selfTestRom}); err != nil {
+		return fmt.Errorf("self-test: %w", err)
+	}
+
+	if err := c.RunHeadless(selfTestMaxSteps); err != nil {
+		return fmt.Errorf("self-test: %w", err)
+	}
+	if !c.IsIdle() {
+		return fmt.Errorf("self-test didn't finish in %d instructions", selfTestMaxSteps)
+	}
+
+	if hash := c.ScreenHash(); hash != selfTestScreenHash {
+		return fmt.Errorf("self-test screen hash is %s, expected %s", hash, selfTestScreenHash)
+	}
+	return nil
+}
+
+// ScreenHash returns the hex encoded sha1 of the screen pixels of the current resolution,
+// one byte per pixel row by row.
+func (c Chip8) ScreenHash() string {
+	width, height := c.ScreenSize()
+	pixels := make([]byte, width*height)
+	for i, on := range c.screen[:width*height] {
+		if on {
+			pixels[i] = 1
+		}
+	}
+
+	sum := sha1.Sum(pixels)
+	return hex.EncodeToString(sum[:])
+}
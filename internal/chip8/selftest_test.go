@@ -0,0 +1,26 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTest(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, SelfTest())
+}
+
+func TestChip8_ScreenHash(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	blank := chip8.ScreenHash()
+
+	chip8.screen[0] = true
+	require.NotEqual(t, blank, chip8.ScreenHash())
+
+	chip8.screen[0] = false
+	require.Equal(t, blank, chip8.ScreenHash())
+}
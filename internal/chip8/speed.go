@@ -0,0 +1,13 @@
+package chip8
+
+// speeds maps sha1 hashes of roms to the instructions per frame they are known to play well with
+var speeds = map[string]int{
+	"1ba58656810b67fd131eb9af3e3987863bf26c90": 10, // IBM Logo
+	"f1cfcffe1937ed6dd6eeed1a7f85dfc777bda700": 20, // test_opcode by corax89
+}
+
+// LookupSpeed returns the recommended instructions per frame for the rom with the given hash.
+func LookupSpeed(hash string) (int, bool) {
+	ipf, ok := speeds[hash]
+	return ipf, ok
+}
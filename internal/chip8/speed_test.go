@@ -0,0 +1,32 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupSpeed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known rom", func(t *testing.T) {
+		rom, err := NewRomFromFile("../../roms/IBM_Logo.ch8")
+		require.NoError(t, err)
+
+		ipf, ok := LookupSpeed(rom.Hash())
+		require.True(t, ok)
+		require.Equal(t, 10, ipf)
+	})
+
+	t.Run("unknown rom", func(t *testing.T) {
+		rom := Rom{
+			Data: []byte{
+				0x12, 0x00, // jump to 0x200
+			},
+		}
+
+		ipf, ok := LookupSpeed(rom.Hash())
+		require.False(t, ok)
+		require.Zero(t, ipf)
+	})
+}
@@ -0,0 +1,30 @@
+package chip8
+
+// spriteRow returns the screen row of the i-th sprite row drawn from posY.
+// false is returned if the row is clipped, the rows after it are clipped too.
+func (c Chip8) spriteRow(posY, i int) (int, bool) {
+	height := c.ScreenHeight()
+	row := posY + i
+	if row < height {
+		return row, true
+	}
+	if c.quirks.SpriteWrapping {
+		return row % height, true
+	}
+	return 0, false
+}
+
+// spriteColumn returns the screen column of the j-th pixel of a sprite row drawn from posX.
+// Columns are wrapped or clipped on their own, regardless of whether the row was wrapped.
+// false is returned if the pixel is clipped, the pixels after it are clipped too.
+func (c Chip8) spriteColumn(posX, j int) (int, bool) {
+	width := c.ScreenWidth()
+	col := posX + j
+	if col < width {
+		return col, true
+	}
+	if c.quirks.SpriteWrapping {
+		return col % width, true
+	}
+	return 0, false
+}
@@ -0,0 +1,69 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SpriteWrapping(t *testing.T) {
+	t.Parallel()
+
+	// a 6 rows tall sprite drawn 2 rows above the bottom edge, 4 pixels left of the right edge
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x3c, // 0x200: v[0] = 60
+			0x61, 0x1e, // 0x202: v[1] = 30
+			0xa2, 0x0a, // 0x204: vI = 0x20A
+			0xd0, 0x16, // 0x206: draw(v[0], v[1], 6)
+			0x12, 0x08, // 0x208: jump to 0x208
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // 0x20A: sprite
+		},
+	}
+
+	draw := func(wrap bool) Chip8 {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetQuirks(Quirks{SpriteWrapping: wrap})
+		for i := 0; i < 4; i++ {
+			chip8.step()
+		}
+		return chip8
+	}
+
+	litPixels := func(chip8 Chip8) int {
+		lit := 0
+		for _, on := range chip8.screen {
+			if on {
+				lit++
+			}
+		}
+		return lit
+	}
+
+	t.Run("clip", func(t *testing.T) {
+		chip8 := draw(false)
+
+		// 2 rows of 4 pixels are left
+		require.Equal(t, 2*4, litPixels(chip8))
+		require.True(t, chip8.ScreenPixelSetAt(60, 30))
+		require.True(t, chip8.ScreenPixelSetAt(63, 31))
+		require.False(t, chip8.ScreenPixelSetAt(60, 0))
+		require.False(t, chip8.ScreenPixelSetAt(0, 30))
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		chip8 := draw(true)
+
+		// the whole sprite is drawn
+		require.Equal(t, 6*8, litPixels(chip8))
+		// the bottom rows
+		require.True(t, chip8.ScreenPixelSetAt(60, 30))
+		require.True(t, chip8.ScreenPixelSetAt(3, 31))
+		// the rows wrapped to the top, including their pixels wrapped to the left
+		require.True(t, chip8.ScreenPixelSetAt(60, 0))
+		require.True(t, chip8.ScreenPixelSetAt(3, 3))
+		require.False(t, chip8.ScreenPixelSetAt(4, 3))
+		require.False(t, chip8.ScreenPixelSetAt(60, 4))
+	})
+}
@@ -0,0 +1,34 @@
+package chip8
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// levels of nested subroutines of the original interpreter
+	defaultStackSize = 16
+	// the stack pointer is a byte
+	maxStackSize = 255
+)
+
+var ErrInvalidStackSize = errors.New("stack size is out of range")
+
+// SetStackSize sets the levels of nested subroutines, 16 by default, e.g. for deeply recursive roms.
+// The calls past the size halt the machine with ErrStackOverflow.
+// The size must be between 1 and 255 and hold the return addresses already on the stack.
+func (c *Chip8) SetStackSize(size int) error {
+	if size < 1 || size > maxStackSize || size < int(c.sp) {
+		return fmt.Errorf("stack size %d: %w", size, ErrInvalidStackSize)
+	}
+
+	stack := make([]uint16, size)
+	copy(stack, c.stack[:c.sp])
+	c.stack = stack
+	return nil
+}
+
+// StackSize returns the levels of nested subroutines.
+func (c Chip8) StackSize() int {
+	return len(c.stack)
+}
@@ -0,0 +1,98 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SetStackSize(t *testing.T) {
+	t.Parallel()
+
+	// calls itself until the stack is full
+	rom := Rom{
+		Data: []byte{
+			0x22, 0x00, // 0x200: call 0x200
+		},
+	}
+
+	t.Run("nests to the limit", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		require.NoError(t, chip8.SetStackSize(64))
+
+		for i := 0; i < 64; i++ {
+			require.NoError(t, chip8.Emulate())
+		}
+		require.Equal(t, uint8(64), chip8.StackPointer())
+		require.Equal(t, StateRunning, chip8.GetState())
+
+		require.ErrorIs(t, chip8.Emulate(), ErrStackOverflow)
+		require.Equal(t, StateHalted, chip8.GetState())
+		require.Equal(t, uint8(64), chip8.StackPointer())
+	})
+
+	t.Run("returns to the limit", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x22, 0x06, // 0x200: call 0x206
+				0x00, 0xfd, // 0x202: exit
+				0x00, 0x00, // 0x204: padding
+				0x32, 0x03, // 0x206: skip the next if v[2] == 3
+				0x12, 0x0e, // 0x208: jump to 0x20E
+				0x00, 0xee, // 0x20A: return
+				0x00, 0x00, // 0x20C: padding
+				0x72, 0x01, // 0x20E: v[2] += 1
+				0x22, 0x06, // 0x210: call 0x206
+				0x00, 0xee, // 0x212: return
+			},
+		})
+		require.NoError(t, chip8.SetStackSize(4))
+
+		require.NoError(t, chip8.RunHeadless(1000))
+		require.Equal(t, StateQuit, chip8.GetState())
+		require.Equal(t, uint8(3), chip8.regsV[2])
+		require.Equal(t, uint8(0), chip8.StackPointer())
+	})
+
+	t.Run("size is kept on reset", func(t *testing.T) {
+		chip8 := NewChip8()
+		require.Equal(t, defaultStackSize, chip8.StackSize())
+		require.NoError(t, chip8.SetStackSize(maxStackSize))
+
+		chip8.LoadRom(rom)
+		chip8.Reset()
+		require.Equal(t, maxStackSize, chip8.StackSize())
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.Emulate()
+		chip8.Emulate()
+
+		for _, size := range []int{0, 1, maxStackSize + 1} {
+			require.ErrorIs(t, chip8.SetStackSize(size), ErrInvalidStackSize, "size %d", size)
+		}
+		require.Equal(t, defaultStackSize, chip8.StackSize())
+
+		require.NoError(t, chip8.SetStackSize(2))
+		require.Equal(t, []uint16{0x202, 0x202}, chip8.stack)
+	})
+
+	t.Run("snapshot copies the stack", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.Emulate()
+		snapshot := chip8.Snapshot()
+
+		chip8.Emulate()
+		require.Equal(t, uint16(0x202), chip8.stack[1])
+		require.Equal(t, uint16(0), snapshot.Stack[1])
+
+		chip8.Restore(snapshot)
+		require.Equal(t, uint8(1), chip8.StackPointer())
+		require.Equal(t, uint16(0), chip8.stack[1])
+	})
+}
@@ -0,0 +1,109 @@
+package chip8
+
+import "slices"
+
+// Snapshot is a copy of everything that changes while a rom is running, a save state.
+// The arrays and the stack are copied, so the machine running on doesn't change a snapshot taken earlier.
+type Snapshot struct {
+	RAM    [ramSizeBytes]byte
+	Screen [screenBufferSize]bool
+	Hires  bool
+	KeyPad [keyPadSize]bool
+
+	RegsV [0x10]uint8
+	RegI  uint16
+	PC    uint16
+
+	// of the stack size of the machine
+	Stack []uint16
+	SP    uint8
+
+	DelayTimer uint8
+	SoundTimer uint8
+
+	// the run state and the error that halted the machine
+	State State
+	Err   error
+
+	// FX0A waits for a key, see the KeyWaitPolicy
+	WaitingForKey  bool
+	KeyWaitKey     uint8
+	KeyWaitPressed bool
+	// the order the keys were pressed in
+	KeyPressOrder [keyPadSize]uint64
+	KeyPresses    uint64
+
+	// the counters and the position in the current frame
+	FrameCount       uint64
+	InstructionCount uint64
+	FrameTime        int
+	DrewThisFrame    bool
+}
+
+// Snapshot returns the current state of the machine to be restored later with Restore.
+func (c Chip8) Snapshot() Snapshot {
+	return Snapshot{
+		RAM:    c.ram,
+		Screen: c.screen,
+		Hires:  c.hires,
+		KeyPad: c.keyPad,
+
+		RegsV: c.regsV,
+		RegI:  c.regI,
+		PC:    c.pc,
+
+		Stack: slices.Clone(c.stack),
+		SP:    c.sp,
+
+		DelayTimer: c.delayTimer,
+		SoundTimer: c.soundTimer,
+
+		State: c.state,
+		Err:   c.err,
+
+		WaitingForKey:  c.waitingForKey,
+		KeyWaitKey:     c.keyWaitKey,
+		KeyWaitPressed: c.keyWaitPressed,
+		KeyPressOrder:  c.keyPressOrder,
+		KeyPresses:     c.keyPresses,
+
+		FrameCount:       c.frameCount,
+		InstructionCount: c.instructionCount,
+		FrameTime:        c.frameTime,
+		DrewThisFrame:    c.drewThisFrame,
+	}
+}
+
+// Restore puts the machine back to the state of the snapshot.
+func (c *Chip8) Restore(s Snapshot) {
+	c.ram = s.RAM
+	c.screen = s.Screen
+	c.hires = s.Hires
+	c.keyPad = s.KeyPad
+
+	c.regsV = s.RegsV
+	c.regI = s.RegI
+	c.pc = s.PC
+
+	// the stack size is kept, a snapshot of another size is cut to fit
+	n := copy(c.stack, s.Stack)
+	clear(c.stack[n:])
+	c.sp = min(s.SP, uint8(len(c.stack)))
+
+	c.delayTimer = s.DelayTimer
+	c.setSoundTimer(s.SoundTimer)
+
+	c.state = s.State
+	c.err = s.Err
+
+	c.waitingForKey = s.WaitingForKey
+	c.keyWaitKey = s.KeyWaitKey
+	c.keyWaitPressed = s.KeyWaitPressed
+	c.keyPressOrder = s.KeyPressOrder
+	c.keyPresses = s.KeyPresses
+
+	c.frameCount = s.FrameCount
+	c.instructionCount = s.InstructionCount
+	c.frameTime = s.FrameTime
+	c.drewThisFrame = s.DrewThisFrame
+}
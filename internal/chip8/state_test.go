@@ -0,0 +1,112 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	chip8.LoadRom(Rom{
+		Data: []byte{
+			0x60, 0x05, // 0x200: v[0] = 5
+			0xa0, 0x00, // 0x202: vI = font sprite of 0
+			0xd0, 0x05, // 0x204: draw(v[0], v[0], 5)
+			0xa3, 0x00, // 0x206: vI = 0x300
+			0xf0, 0x55, // 0x208: store v[0] at 0x300
+			0xf0, 0x15, // 0x20A: delay timer = v[0]
+			0x22, 0x10, // 0x20C: call 0x210
+			0x00, 0x00, // 0x20E
+			0x70, 0x01, // 0x210: v[0] += 1
+			0x12, 0x10, // 0x212: jump to 0x210
+		},
+	})
+	chip8.step()
+	require.NoError(t, chip8.SetKey(0xa, true))
+
+	snapshot := chip8.Snapshot()
+	saved := snapshot
+
+	for i := 0; i < 8; i++ {
+		chip8.step()
+	}
+	require.NoError(t, chip8.SetKey(0xa, false))
+	require.NotEqual(t, snapshot, chip8.Snapshot())
+	require.Equal(t, saved, snapshot, "running the machine doesn't change the snapshot")
+
+	chip8.Restore(snapshot)
+	require.Equal(t, snapshot, chip8.Snapshot())
+	require.Equal(t, uint16(0x202), chip8.pc)
+	require.Equal(t, uint8(5), chip8.regsV[0])
+	require.Equal(t, uint8(0), chip8.ram[0x300])
+	require.Equal(t, uint8(0), chip8.sp)
+	require.Equal(t, uint8(0), chip8.DelayTimer())
+	require.False(t, chip8.ScreenPixelSetAt(5, 5))
+	require.True(t, chip8.KeyIsPressed(0xa))
+}
+
+func TestChip8_SnapshotRestore_RunState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("waiting for a key", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0xf0, 0x0a, // 0x200: wait for a key to v[0]
+				0x12, 0x02, // 0x202: jump to 0x202
+			},
+		})
+		chip8.step()
+		require.True(t, chip8.IsWaitingForKey())
+		snapshot := chip8.Snapshot()
+
+		require.NoError(t, chip8.SetKey(0x5, true))
+		chip8.step()
+		require.NoError(t, chip8.SetKey(0x5, false))
+		chip8.step()
+		chip8.step()
+		require.False(t, chip8.IsWaitingForKey())
+
+		chip8.Restore(snapshot)
+		require.Equal(t, snapshot, chip8.Snapshot())
+		require.True(t, chip8.IsWaitingForKey())
+		chip8.step()
+		require.Equal(t, uint16(0x200), chip8.pc, "still waits for a key")
+	})
+
+	t.Run("halted", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				0x00, 0xee, // 0x200: return with the empty stack
+			},
+		})
+		running := chip8.Snapshot()
+		chip8.step()
+		require.Equal(t, StateHalted, chip8.GetState())
+		halted := chip8.Snapshot()
+
+		chip8.Restore(running)
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.NoError(t, chip8.Err())
+
+		chip8.Restore(halted)
+		require.Equal(t, StateHalted, chip8.GetState())
+		require.ErrorIs(t, chip8.Err(), ErrStackUnderflow)
+		require.Equal(t, halted, chip8.Snapshot())
+	})
+
+	t.Run("a shorter stack", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.stack[0] = 0x202
+		chip8.stack[15] = 0x20e
+		chip8.Restore(Snapshot{Stack: []uint16{0x300}, SP: 1})
+
+		expected := make([]uint16, defaultStackSize)
+		expected[0] = 0x300
+		require.Equal(t, expected, chip8.stack, "the old entries past the snapshot are cleared")
+	})
+}
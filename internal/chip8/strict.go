@@ -0,0 +1,34 @@
+package chip8
+
+import "fmt"
+
+// UnknownOpcodeError is the error of an unimplemented or undocumented opcode in strict mode,
+// e.g. 5XYN and 9XYN with N other than 0.
+type UnknownOpcodeError struct {
+	PC     uint16
+	Opcode uint16
+}
+
+func (e *UnknownOpcodeError) Error() string {
+	return fmt.Sprintf("%04X: unknown opcode %04X", e.PC, e.Opcode)
+}
+
+// SetStrictMode sets whether an unknown opcode halts the machine with UnknownOpcodeError.
+// The unknown opcodes are skipped by default, like most interpreters do.
+func (c *Chip8) SetStrictMode(strict bool) {
+	c.strict = strict
+}
+
+// StrictMode reports whether an unknown opcode halts the machine.
+func (c Chip8) StrictMode() bool {
+	return c.strict
+}
+
+// unknownOpcode halts the machine on the opcode at the pc in strict mode and reports whether it's halted.
+func (c *Chip8) unknownOpcode(pc, opcode uint16) bool {
+	if !c.strict {
+		return false
+	}
+	c.halt(&UnknownOpcodeError{PC: pc, Opcode: opcode})
+	return true
+}
@@ -0,0 +1,69 @@
+package chip8
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_StrictMode(t *testing.T) {
+	t.Parallel()
+
+	opcodes := map[string][2]byte{
+		"0NNN": {0x02, 0x34},
+		"5XYN": {0x50, 0x11},
+		"8XYN": {0x80, 0x18},
+		"9XYN": {0x90, 0x1f},
+		"EXNN": {0xe0, 0x00},
+		"FXNN": {0xf0, 0xff},
+	}
+
+	newChip8 := func(opcode [2]byte, strict bool) Chip8 {
+		chip8 := NewChip8()
+		chip8.LoadRom(Rom{
+			Data: []byte{
+				opcode[0], opcode[1], // 0x200: unknown opcode
+				0x60, 0x01, // 0x202: v[0] = 1
+			},
+		})
+		chip8.SetStrictMode(strict)
+		return chip8
+	}
+
+	for name, opcode := range opcodes {
+		t.Run(name+" strict", func(t *testing.T) {
+			chip8 := newChip8(opcode, true)
+
+			err := chip8.Emulate()
+			var unknown *UnknownOpcodeError
+			require.True(t, errors.As(err, &unknown), "error: %v", err)
+			require.Equal(t, UnknownOpcodeError{PC: entryPoint, Opcode: uint16(opcode[0])<<8 | uint16(opcode[1])}, *unknown)
+			require.Equal(t, StateHalted, chip8.GetState())
+			require.Equal(t, uint16(entryPoint+2), chip8.pc)
+		})
+
+		t.Run(name+" lenient", func(t *testing.T) {
+			chip8 := newChip8(opcode, false)
+
+			require.NoError(t, chip8.Emulate())
+			require.NoError(t, chip8.Emulate())
+			require.Equal(t, StateRunning, chip8.GetState())
+			require.Equal(t, uint8(1), chip8.regsV[0])
+		})
+	}
+
+	t.Run("known opcodes run", func(t *testing.T) {
+		chip8 := newChip8([2]byte{0x80, 0x10}, true)
+
+		require.NoError(t, chip8.Emulate())
+		require.NoError(t, chip8.Emulate())
+		require.Equal(t, uint8(1), chip8.regsV[0])
+	})
+
+	t.Run("error message", func(t *testing.T) {
+		err := &UnknownOpcodeError{PC: 0x204, Opcode: 0x5121}
+
+		require.Equal(t, "0204: unknown opcode 5121", err.Error())
+	})
+}
@@ -0,0 +1,65 @@
+package chip8
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// thumbnails of the same rom are the same
+const thumbnailSeed = 0xc8
+
+// WriteThumbnail runs the loaded rom for the number of frames without a window and writes a PNG of the final screen,
+// see CaptureImage for fg, bg, and scale. Instructions per frame follow the tps.
+// The random numbers are seeded with a fixed seed, so the same rom always gets the same thumbnail.
+func (c *Chip8) WriteThumbnail(w io.Writer, frames int, fg, bg color.Color, scale int) error {
+	c.SetRandSeed(thumbnailSeed)
+
+	for i := 0; i < frames && !c.stopped(); i++ {
+		c.runFrame(c.instructionsPerFrame())
+	}
+
+	if err := png.Encode(w, c.CaptureFrameImage(fg, bg, scale)); err != nil {
+		return fmt.Errorf("encode thumbnail: %w", err)
+	}
+	return nil
+}
+
+// RunUntilStable runs the loaded rom without a window until the screen stays the same
+// for stableFrames frames in a row, e.g. a title or game over screen, and returns the image of it,
+// see CaptureImage for fg, bg, and scale. A blank screen isn't stable, games clear the screen before they draw anything.
+// It gives up after maxSteps instructions and returns the last frame and false.
+func (c *Chip8) RunUntilStable(maxSteps, stableFrames int, fg, bg color.Color, scale int) (image.Image, bool) {
+	ipf := c.instructionsPerFrame()
+
+	unchanged := 0
+	prev := c.screen
+	for steps := 0; steps+ipf <= maxSteps && !c.stopped(); steps += ipf {
+		c.runFrame(ipf)
+
+		if c.screen != prev || c.screen == [screenBufferSize]bool{} {
+			unchanged = 0
+			prev = c.screen
+			continue
+		}
+		unchanged++
+		if unchanged >= stableFrames {
+			return c.CaptureFrameImage(fg, bg, scale), true
+		}
+	}
+	return c.CaptureFrameImage(fg, bg, scale), false
+}
+
+func (c Chip8) instructionsPerFrame() int {
+	return max(c.InstructionRate()/FramesPerSecond, 1)
+}
+
+// runFrame executes the instructions of a frame and ends it.
+func (c *Chip8) runFrame(ipf int) {
+	for i := 0; i < ipf; i++ {
+		c.step()
+	}
+	c.EndFrame()
+}
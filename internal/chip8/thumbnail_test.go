@@ -0,0 +1,101 @@
+package chip8
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_WriteThumbnail(t *testing.T) {
+	t.Parallel()
+
+	fg := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	bg := color.RGBA{A: 0xff}
+	scale := 2
+
+	rom := Rom{
+		Data: []byte{
+			0xc0, 0x0f, // 0x200: v[0] = rand() & 0x0f
+			0xf0, 0x29, // 0x202: vI = font sprite of v[0]
+			0x61, 0x00, // 0x204: v[1] = 0
+			0xd1, 0x15, // 0x206: draw(v[1], v[1], 5)
+			0x12, 0x08, // 0x208: jump to 0x208
+		},
+	}
+
+	thumbnail := func() []byte {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		var buf bytes.Buffer
+		require.NoError(t, chip8.WriteThumbnail(&buf, 10, fg, bg, scale))
+		return buf.Bytes()
+	}
+
+	data := thumbnail()
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, screenWidth*scale, img.Bounds().Dx())
+	require.Equal(t, screenHeight*scale, img.Bounds().Dy())
+
+	// the digit is drawn in the top left corner
+	lit := 0
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			if color.RGBAModel.Convert(img.At(x, y)) == fg {
+				require.Less(t, x, 4*scale)
+				require.Less(t, y, 5*scale)
+				lit++
+			}
+		}
+	}
+	require.Positive(t, lit)
+
+	// the random digit is the same in every thumbnail
+	require.Equal(t, data, thumbnail())
+}
+
+func TestChip8_RunUntilStable(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0xa0, 0x00, // 0x200: vI = font sprite of 0
+			0x60, 0x00, // 0x202: v[0] = 0
+			0xd0, 0x05, // 0x204: draw(v[0], v[0], 5)
+			0x70, 0x05, // 0x206: v[0] += 5
+			0xd0, 0x05, // 0x208: draw(v[0], v[0], 5)
+			0x12, 0x0a, // 0x20A: jump to 0x20A
+		},
+	}
+
+	t.Run("draws then idles", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		img, ok := chip8.RunUntilStable(100, 3, color.White, color.Black, 2)
+		require.True(t, ok)
+
+		// both sprites are drawn
+		require.Equal(t, image.Rect(0, 0, 2*64, 2*32), img.Bounds())
+		require.Equal(t, chip8.CaptureImage(color.White, color.Black, 2), img)
+		require.Equal(t, color.RGBAModel.Convert(color.White), img.At(1, 1))
+		require.Equal(t, color.RGBAModel.Convert(color.White), img.At(11, 11))
+		require.True(t, chip8.IsIdle())
+	})
+
+	t.Run("step budget", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		// the second sprite isn't drawn yet
+		img, ok := chip8.RunUntilStable(4, 3, color.White, color.Black, 1)
+		require.False(t, ok)
+		require.Equal(t, color.RGBAModel.Convert(color.White), img.At(0, 0))
+		require.Equal(t, color.RGBAModel.Convert(color.Black), img.At(5, 5))
+	})
+}
@@ -0,0 +1,49 @@
+package chip8
+
+// tickTimers counts the delay and sound timers down.
+func (c *Chip8) tickTimers() {
+	if c.delayTimer > 0 {
+		c.delayTimer--
+	}
+	if c.soundTimer > 0 {
+		c.setSoundTimer(c.soundTimer - 1)
+	}
+}
+
+// setSoundTimer updates the sound timer, the beep starts when the timer becomes active
+// and stops when it reaches zero.
+func (c *Chip8) setSoundTimer(value uint8) {
+	prev := c.soundTimer
+	c.soundTimer = value
+
+	if c.soundPlayer == nil {
+		return
+	}
+	switch {
+	case prev == 0 && value > 0:
+		c.soundPlayer.Play()
+	case prev > 0 && value == 0:
+		c.soundPlayer.Stop()
+	}
+}
+
+// countFrame counts an executed instruction. A frame ends every 1/60 second of the instruction time
+// at the InstructionRate, and the timers tick once per frame, so they count down at 60 Hz
+// regardless of the tps and the max ips. At the rate below 60 a single instruction may take several frames.
+func (c *Chip8) countFrame() {
+	rate := c.InstructionRate()
+	c.frameTime += FramesPerSecond
+	for c.frameTime >= rate {
+		c.frameTime -= rate
+		c.frameCount++
+		c.drewThisFrame = false
+		c.rewindSaved = false
+		c.tickTimers()
+	}
+}
+
+// FrameCount returns the number of 60 Hz frames the rom has run since the start or the last Reset.
+// Restoring a snapshot turns it back to the one of the snapshot.
+func (c Chip8) FrameCount() uint64 {
+	return c.frameCount
+}
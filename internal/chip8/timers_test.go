@@ -0,0 +1,203 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_FrameCount(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x70, 0x01, // 0x200: v[0] += 1
+			0x12, 0x00, // 0x202: jump to 0x200
+		},
+	}
+
+	t.Run("instructions per frame", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		// 10 instructions per frame
+		chip8.SetTPS(10 * FramesPerSecond)
+		require.Equal(t, uint64(0), chip8.FrameCount())
+
+		for i := 0; i < 9; i++ {
+			chip8.step()
+		}
+		require.Equal(t, uint64(0), chip8.FrameCount())
+
+		chip8.step()
+		require.Equal(t, uint64(1), chip8.FrameCount())
+
+		for i := 0; i < 1000; i++ {
+			chip8.step()
+		}
+		require.Equal(t, uint64(101), chip8.FrameCount())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		for i := 0; i < 5; i++ {
+			chip8.step()
+		}
+		require.Equal(t, uint64(5), chip8.FrameCount())
+
+		chip8.Reset()
+		require.Equal(t, uint64(0), chip8.FrameCount())
+	})
+}
+
+type fakeSoundPlayer struct {
+	playing bool
+	plays   int
+}
+
+func (p *fakeSoundPlayer) Play() {
+	p.playing = true
+	p.plays++
+}
+
+func (p *fakeSoundPlayer) Stop() {
+	p.playing = false
+}
+
+func TestChip8_SoundPlayer(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x03, // 0x200: v[0] = 3
+			0xf0, 0x18, // 0x202: sound timer = v[0]
+			0xf0, 0x18, // 0x204: sound timer = v[0]
+			0x12, 0x06, // 0x206: jump to 0x206
+		},
+	}
+
+	t.Run("beep while the timer is active", func(t *testing.T) {
+		player := &fakeSoundPlayer{}
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetSoundPlayer(player)
+
+		chip8.step()
+		require.False(t, player.playing)
+
+		chip8.step()
+		require.True(t, player.playing)
+
+		// setting the active timer again doesn't restart the beep
+		chip8.step()
+		require.True(t, player.playing)
+		require.Equal(t, 1, player.plays)
+
+		for chip8.SoundTimer() > 0 {
+			require.True(t, player.playing)
+			chip8.step()
+		}
+		require.False(t, player.playing)
+	})
+
+	t.Run("no player", func(t *testing.T) {
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+
+		for i := 0; i < 10; i++ {
+			chip8.step()
+		}
+		require.Zero(t, chip8.SoundTimer())
+	})
+}
+
+func TestChip8_TimersRate(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0xff, // 0x200: v[0] = 0xff
+			0xf0, 0x15, // 0x202: delay timer = v[0]
+			0x12, 0x04, // 0x204: jump to 0x204
+		},
+	}
+
+	tests := []struct {
+		name string
+		tps  int
+	}{
+		{"fast", 10 * FramesPerSecond},
+		{"uneven", 500},
+		{"slow", FramesPerSecond / 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chip8 := NewChip8()
+			chip8.LoadRom(rom)
+			chip8.SetTPS(tt.tps)
+
+			chip8.Emulate()
+			chip8.Emulate()
+			frames := chip8.FrameCount()
+			delay := chip8.DelayTimer()
+
+			// a second of instructions
+			for i := 0; i < tt.tps; i++ {
+				chip8.Emulate()
+			}
+
+			require.Equal(t, uint64(FramesPerSecond), chip8.FrameCount()-frames)
+			require.Equal(t, delay-FramesPerSecond, chip8.DelayTimer())
+		})
+	}
+}
+
+func TestChip8_SetCyclesPerFrame(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0xff, // 0x200: v[0] = 0xff
+			0xf0, 0x15, // 0x202: delay timer = v[0]
+			0x71, 0x01, // 0x204: v[1] += 1
+			0x12, 0x04, // 0x206: jump to 0x204
+		},
+	}
+
+	const cycles = 12
+
+	chip8 := NewChip8()
+	chip8.LoadRom(rom)
+	require.NoError(t, chip8.SetCyclesPerFrame(cycles))
+	require.Equal(t, cycles*FramesPerSecond, chip8.GetTPS())
+	require.Equal(t, cycles, chip8.CyclesPerFrame())
+
+	// the rest of the first frame
+	for i := 0; i < cycles; i++ {
+		chip8.Step()
+	}
+	require.Equal(t, uint64(1), chip8.FrameCount())
+	require.Equal(t, uint8(0xfe), chip8.DelayTimer())
+
+	instructions := chip8.InstructionCount()
+	for i := 0; i < cycles; i++ {
+		chip8.Step()
+	}
+	require.Equal(t, uint64(cycles), chip8.InstructionCount()-instructions)
+	require.Equal(t, uint64(2), chip8.FrameCount(), "a frame per the cycles")
+	require.Equal(t, uint8(0xfd), chip8.DelayTimer(), "the timer ticks once per frame")
+}
+
+func TestChip8_SetCyclesPerFrame_OutOfRange(t *testing.T) {
+	t.Parallel()
+
+	chip8 := NewChip8()
+	require.NoError(t, chip8.SetCyclesPerFrame(MaxCyclesPerFrame))
+	require.Equal(t, MaxCyclesPerFrame, chip8.CyclesPerFrame())
+
+	for _, n := range []int{0, -1, MaxCyclesPerFrame + 1, 100} {
+		require.ErrorIs(t, chip8.SetCyclesPerFrame(n), ErrInvalidCyclesPerFrame, "%d cycles", n)
+	}
+	require.Equal(t, MaxCyclesPerFrame, chip8.CyclesPerFrame(), "the tps isn't changed")
+}
@@ -0,0 +1,80 @@
+package chip8
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SetTraceWriter sets where executed instructions are traced to. nil disables tracing and is default.
+func (c *Chip8) SetTraceWriter(w io.Writer) {
+	c.trace = w
+}
+
+// TraceEvent is an executed instruction passed to the trace func.
+type TraceEvent struct {
+	// address of the instruction
+	PC     uint16
+	Opcode uint16
+
+	// the opcode decoded, e.g. 8XY4
+	X   uint8
+	Y   uint8
+	N   uint8
+	NN  uint8
+	NNN uint16
+
+	// what the instruction did, e.g. "v0 = 05"
+	Description string
+}
+
+// SetTraceFunc sets the func every executed instruction is passed to, e.g. for debuggers.
+// It's called after the instruction is executed. nil disables it and is default.
+func (c *Chip8) SetTraceFunc(f func(TraceEvent)) {
+	c.traceFunc = f
+}
+
+// SetTraceFilter traces only the instructions with the mnemonics to the trace writer and the trace func, e.g. "DRW" or "LD",
+// see DisassembleOpcode. The case is ignored. No mnemonics trace every instruction.
+func (c *Chip8) SetTraceFilter(mnemonics ...string) {
+	if len(mnemonics) == 0 {
+		c.traceFilter = nil
+		return
+	}
+
+	c.traceFilter = make(map[string]bool, len(mnemonics))
+	for _, mnemonic := range mnemonics {
+		c.traceFilter[strings.ToUpper(mnemonic)] = true
+	}
+}
+
+// traceInstruction traces the instruction at pc to the trace writer and the trace func.
+func (c Chip8) traceInstruction(pc, opcode uint16, description string) {
+	if c.trace == nil && c.traceFunc == nil {
+		return
+	}
+	if c.traceFilter != nil {
+		mnemonic, _, _ := strings.Cut(DisassembleOpcode(opcode), " ")
+		if !c.traceFilter[mnemonic] {
+			return
+		}
+	}
+
+	if c.trace != nil {
+		fmt.Fprintf(c.trace, "%04X: %04X %s\n", pc, opcode, description)
+	}
+	if c.traceFunc != nil {
+		c.traceFunc(TraceEvent{
+			PC:     pc,
+			Opcode: opcode,
+
+			X:   uint8((opcode >> 8) & 0x0f),
+			Y:   uint8((opcode >> 4) & 0x0f),
+			N:   uint8(opcode & 0x000f),
+			NN:  uint8(opcode & 0x00ff),
+			NNN: opcode & 0x0fff,
+
+			Description: description,
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package chip8
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_SetTraceFilter(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x05, // 0x200: v[0] = 5
+			0xa0, 0x00, // 0x202: vI = 0x000
+			0xd0, 0x05, // 0x204: draw(v[0], v[0], 5)
+			0x70, 0x01, // 0x206: v[0] += 1
+			0xd0, 0x05, // 0x208: draw(v[0], v[0], 5)
+		},
+	}
+
+	trace := func(mnemonics ...string) []string {
+		var buf bytes.Buffer
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetTraceWriter(&buf)
+		chip8.SetTraceFilter(mnemonics...)
+		for i := 0; i < len(rom.Data)/2; i++ {
+			chip8.step()
+		}
+		return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	}
+
+	t.Run("draw only", func(t *testing.T) {
+		lines := trace("drw")
+		require.Len(t, lines, 2)
+		require.True(t, strings.HasPrefix(lines[0], "0204: D005 "), lines[0])
+		require.True(t, strings.HasPrefix(lines[1], "0208: D005 "), lines[1])
+	})
+
+	t.Run("several mnemonics", func(t *testing.T) {
+		lines := trace("DRW", "ADD")
+		require.Len(t, lines, 3)
+		require.True(t, strings.HasPrefix(lines[1], "0206: 7001 "), lines[1])
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		require.Len(t, trace(), len(rom.Data)/2)
+	})
+}
+
+func TestChip8_SetTraceWriter(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x05, // 0x200: v[0] = 5
+			0x70, 0x01, // 0x202: v[0] += 1
+		},
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		chip8 := NewChip8()
+		require.Nil(t, chip8.trace)
+	})
+
+	t.Run("writer", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		chip8 := NewChip8()
+		chip8.LoadRom(rom)
+		chip8.SetTraceWriter(&buf)
+		chip8.step()
+		chip8.step()
+
+		require.Equal(t, "0200: 6005 v0 = 05\n0202: 7001 v0 += 01 without flags\n", buf.String())
+	})
+}
+
+func TestChip8_SetTraceFunc(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x05, // 0x200: v[0] = 5
+			0x22, 0x06, // 0x202: call 0x206
+			0x00, 0x00, // 0x204
+			0x81, 0x04, // 0x206: v[1] += v[0]
+			0x00, 0xee, // 0x208: return
+		},
+	}
+
+	var events []TraceEvent
+	chip8 := NewChip8()
+	chip8.LoadRom(rom)
+	chip8.SetTraceFunc(func(event TraceEvent) {
+		events = append(events, event)
+	})
+	for i := 0; i < 4; i++ {
+		chip8.step()
+	}
+
+	require.Len(t, events, 4)
+	pcs := []uint16{0x200, 0x202, 0x206, 0x208}
+	opcodes := []uint16{0x6005, 0x2206, 0x8104, 0x00ee}
+	for i, event := range events {
+		require.Equal(t, pcs[i], event.PC)
+		require.Equal(t, opcodes[i], event.Opcode)
+	}
+
+	require.Equal(t, TraceEvent{
+		PC:     0x206,
+		Opcode: 0x8104,
+
+		X:   0x1,
+		Y:   0x0,
+		N:   0x4,
+		NN:  0x04,
+		NNN: 0x104,
+
+		Description: events[2].Description,
+	}, events[2])
+	require.NotEmpty(t, events[2].Description)
+	require.Equal(t, "v0 = 05", events[0].Description)
+}
@@ -0,0 +1,119 @@
+package chip8
+
+import (
+	"fmt"
+	"slices"
+)
+
+// WatchKind is what a watchpoint watches.
+type WatchKind int
+
+const (
+	WatchMemory WatchKind = iota
+	WatchRegister
+)
+
+func (k WatchKind) String() string {
+	switch k {
+	case WatchMemory:
+		return "memory"
+	case WatchRegister:
+		return "register"
+	}
+	return fmt.Sprintf("WatchKind(%d)", int(k))
+}
+
+// WatchHit is the change of a watched location that paused the machine.
+type WatchHit struct {
+	Kind WatchKind
+	// address of the memory or index of the V register
+	Addr uint16
+	// address of the instruction that changed the location
+	PC uint16
+
+	Old, New uint8
+}
+
+// AddMemoryWatch pauses the machine after an instruction executed by Emulate changes the byte of ram at addr.
+// Writes of the same value don't pause it. The addresses out of ram are ignored.
+func (c *Chip8) AddMemoryWatch(addr uint16) {
+	if int(addr) >= ramSizeBytes {
+		return
+	}
+	i, found := slices.BinarySearch(c.memoryWatches, addr)
+	if !found {
+		c.memoryWatches = slices.Insert(c.memoryWatches, i, addr)
+	}
+}
+
+// RemoveMemoryWatch removes the watch of the ram at addr, if any.
+func (c *Chip8) RemoveMemoryWatch(addr uint16) {
+	if i, found := slices.BinarySearch(c.memoryWatches, addr); found {
+		c.memoryWatches = slices.Delete(c.memoryWatches, i, i+1)
+	}
+}
+
+// AddRegisterWatch pauses the machine after an instruction executed by Emulate changes the V register.
+// Writes of the same value don't pause it. The registers above VF are ignored.
+func (c *Chip8) AddRegisterWatch(reg uint8) {
+	if int(reg) < len(c.regsV) {
+		c.registerWatches[reg] = true
+	}
+}
+
+// RemoveRegisterWatch removes the watch of the V register, if any.
+func (c *Chip8) RemoveRegisterWatch(reg uint8) {
+	if int(reg) < len(c.regsV) {
+		c.registerWatches[reg] = false
+	}
+}
+
+// LastWatchHit returns the watch that paused the machine last.
+// false is returned if no watch has fired since the rom was loaded or reset.
+func (c Chip8) LastWatchHit() (WatchHit, bool) {
+	return c.watchHit, c.watchHitOK
+}
+
+// watchedValues are the values of the watched locations before an instruction
+type watchedValues struct {
+	pc     uint16
+	regs   [0x10]uint8
+	memory []byte
+}
+
+// recordWatches returns the current values of the watched locations.
+// false is returned if nothing is watched.
+func (c *Chip8) recordWatches() (watchedValues, bool) {
+	if len(c.memoryWatches) == 0 && c.registerWatches == [0x10]bool{} {
+		return watchedValues{}, false
+	}
+
+	c.watchBuf = c.watchBuf[:0]
+	for _, addr := range c.memoryWatches {
+		c.watchBuf = append(c.watchBuf, c.ram[addr])
+	}
+	return watchedValues{
+		pc:     c.pc,
+		regs:   c.regsV,
+		memory: c.watchBuf,
+	}, true
+}
+
+// hitWatch records the first changed location, the registers before the memory, and reports whether one changed.
+func (c *Chip8) hitWatch(before watchedValues) bool {
+	for reg, watched := range c.registerWatches {
+		if watched && c.regsV[reg] != before.regs[reg] {
+			c.watchHit = WatchHit{Kind: WatchRegister, Addr: uint16(reg), PC: before.pc, Old: before.regs[reg], New: c.regsV[reg]}
+			c.watchHitOK = true
+			return true
+		}
+	}
+	for i, addr := range c.memoryWatches {
+		if c.ram[addr] != before.memory[i] {
+			c.watchHit = WatchHit{Kind: WatchMemory, Addr: addr, PC: before.pc, Old: before.memory[i], New: c.ram[addr]}
+			c.watchHitOK = true
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package chip8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChip8_Watch(t *testing.T) {
+	t.Parallel()
+
+	rom := Rom{
+		Data: []byte{
+			0x60, 0x01, // 0x200: v[0] = 1
+			0x61, 0x02, // 0x202: v[1] = 2
+			0xa3, 0x00, // 0x204: vI = 0x300
+			0xf1, 0x55, // 0x206: store v[0], v[1] from vI
+			0x61, 0x02, // 0x208: v[1] = 2
+			0x12, 0x08, // 0x20A: jump to 0x208
+		},
+	}
+
+	newChip8 := func() Chip8 {
+		chip8 := NewChip8()
+		chip8.SetTPS(maxTPS)
+		chip8.LoadRom(rom)
+		return chip8
+	}
+
+	t.Run("register", func(t *testing.T) {
+		chip8 := newChip8()
+		chip8.AddRegisterWatch(0x1)
+
+		chip8.Emulate()
+		require.Equal(t, StateRunning, chip8.GetState())
+		_, ok := chip8.LastWatchHit()
+		require.False(t, ok)
+
+		chip8.Emulate()
+		require.Equal(t, StatePaused, chip8.GetState())
+		require.Equal(t, uint16(0x204), chip8.pc, "paused after the write")
+		hit, ok := chip8.LastWatchHit()
+		require.True(t, ok)
+		require.Equal(t, WatchHit{Kind: WatchRegister, Addr: 0x1, PC: 0x202, Old: 0, New: 2}, hit)
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		chip8 := newChip8()
+		chip8.AddMemoryWatch(0x301)
+
+		for i := 0; i < 4; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, StatePaused, chip8.GetState())
+		require.Equal(t, uint16(0x208), chip8.pc)
+		hit, ok := chip8.LastWatchHit()
+		require.True(t, ok)
+		require.Equal(t, WatchHit{Kind: WatchMemory, Addr: 0x301, PC: 0x206, Old: 0, New: 2}, hit)
+	})
+
+	t.Run("same value", func(t *testing.T) {
+		chip8 := newChip8()
+		for i := 0; i < 4; i++ {
+			chip8.Emulate()
+		}
+		chip8.AddRegisterWatch(0x1)
+
+		// 0x208 writes 2 to v[1] again
+		for i := 0; i < 10; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, StateRunning, chip8.GetState())
+	})
+
+	t.Run("resumes", func(t *testing.T) {
+		chip8 := newChip8()
+		chip8.AddRegisterWatch(0x0)
+
+		chip8.Emulate()
+		require.Equal(t, StatePaused, chip8.GetState())
+
+		chip8.TogglePause()
+		for i := 0; i < 3; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, StateRunning, chip8.GetState())
+		require.Equal(t, uint16(0x208), chip8.pc)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		chip8 := newChip8()
+		chip8.AddRegisterWatch(0x1)
+		chip8.AddMemoryWatch(0x300)
+		chip8.RemoveRegisterWatch(0x1)
+		chip8.RemoveMemoryWatch(0x300)
+
+		for i := 0; i < 6; i++ {
+			chip8.Emulate()
+		}
+		require.Equal(t, StateRunning, chip8.GetState())
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		chip8 := newChip8()
+		chip8.AddRegisterWatch(0x10)
+		chip8.AddMemoryWatch(ramSizeBytes)
+
+		require.Empty(t, chip8.memoryWatches)
+		require.Equal(t, [0x10]bool{}, chip8.registerWatches)
+	})
+
+	t.Run("reset clears the hit", func(t *testing.T) {
+		chip8 := newChip8()
+		chip8.AddRegisterWatch(0x0)
+		chip8.Emulate()
+
+		chip8.Reset()
+		_, ok := chip8.LastWatchHit()
+		require.False(t, ok)
+	})
+}
+
+func TestWatchKind_String(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "register", WatchRegister.String())
+	require.Equal(t, "WatchKind(7)", WatchKind(7).String())
+}
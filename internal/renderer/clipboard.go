@@ -0,0 +1,49 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"sync"
+
+	"golang.design/x/clipboard"
+)
+
+// the screen is copied to the clipboard scaled up to be readable
+const clipboardScale = 10
+
+// Clipboard receives images of the screen encoded as PNG.
+type Clipboard interface {
+	WriteImage(png []byte) error
+}
+
+// systemClipboard writes to the clipboard of the OS
+type systemClipboard struct {
+	once sync.Once
+	err  error
+}
+
+func (c *systemClipboard) WriteImage(data []byte) error {
+	c.once.Do(func() {
+		c.err = clipboard.Init()
+	})
+	if c.err != nil {
+		return fmt.Errorf("couldn't init the clipboard: %w", c.err)
+	}
+
+	clipboard.Write(clipboard.FmtImage, data)
+	return nil
+}
+
+// CopyScreenToClipboard copies the last drawn screen as a scaled and colored image.
+func (r *Renderer) CopyScreenToClipboard() error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, r.chip8.CaptureFrameImage(r.palette.Color(1), r.palette.Color(0), clipboardScale)); err != nil {
+		return fmt.Errorf("couldn't encode the screen: %w", err)
+	}
+
+	if err := r.clipboard.WriteImage(buf.Bytes()); err != nil {
+		return fmt.Errorf("couldn't copy the screen to the clipboard: %w", err)
+	}
+	return nil
+}
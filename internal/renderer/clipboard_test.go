@@ -0,0 +1,58 @@
+package renderer
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClipboard struct {
+	data []byte
+}
+
+func (c *fakeClipboard) WriteImage(data []byte) error {
+	c.data = data
+	return nil
+}
+
+func TestRenderer_CopyScreenToClipboard(t *testing.T) {
+	t.Parallel()
+
+	fgColor := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	bgColor := color.RGBA{A: 0xff}
+
+	machine := chip8.NewChip8()
+	machine.LoadRom(chip8.Rom{
+		Data: []byte{
+			0xa0, 0x00, // vI = 0x000, font sprite of 0
+			0xd0, 0x05, // draw(0, 0, 5)
+		},
+	})
+	machine.Emulate()
+	machine.Emulate()
+
+	clipboard := &fakeClipboard{}
+	r := &Renderer{
+		chip8:     &machine,
+		palette:   Palette{bgColor, fgColor},
+		clipboard: clipboard,
+	}
+
+	require.NoError(t, r.CopyScreenToClipboard())
+
+	img, err := png.Decode(bytes.NewReader(clipboard.data))
+	require.NoError(t, err)
+
+	w, h := machine.ScreenSize()
+	require.Equal(t, w*clipboardScale, img.Bounds().Dx())
+	require.Equal(t, h*clipboardScale, img.Bounds().Dy())
+
+	// the top left pixel of 0 is on, the pixel right of the sprite is off
+	require.Equal(t, fgColor, color.RGBAModel.Convert(img.At(0, 0)))
+	require.Equal(t, fgColor, color.RGBAModel.Convert(img.At(clipboardScale-1, clipboardScale-1)))
+	require.Equal(t, bgColor, color.RGBAModel.Convert(img.At(4*clipboardScale, 0)))
+}
@@ -0,0 +1,74 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/nevisdale/go-chip8/internal/chip8"
+)
+
+const (
+	// the size of a character of the debug font
+	debugCharWidth  = 6
+	debugCharHeight = 16
+	// the margin around the overlay text
+	debugMargin = 4
+)
+
+// registerView is the state of the machine shown by the debug overlay
+type registerView struct {
+	v          [0x10]uint8
+	i, pc      uint16
+	sp         uint8
+	delayTimer uint8
+	soundTimer uint8
+}
+
+func readRegisters(c *chip8.Chip8) registerView {
+	view := registerView{
+		v:  c.Registers(),
+		i:  c.IndexRegister(),
+		pc: c.ProgramCounter(),
+		sp: c.StackPointer(),
+	}
+	view.delayTimer, view.soundTimer = c.Timers()
+	return view
+}
+
+// formatRegisters returns the text of the debug overlay, 4 V registers per line
+// followed by the address registers and the timers.
+func formatRegisters(view registerView) string {
+	var sb strings.Builder
+	for i, v := range view.v {
+		fmt.Fprintf(&sb, "V%X=%02X", i, v)
+		if i%4 == 3 {
+			sb.WriteByte('\n')
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+	fmt.Fprintf(&sb, "I=%04X PC=%04X SP=%X\n", view.i, view.pc, view.sp)
+	fmt.Fprintf(&sb, "DT=%02X ST=%02X", view.delayTimer, view.soundTimer)
+	return sb.String()
+}
+
+// drawDebugOverlay draws the registers at the top left corner of the final screen.
+func (r *Renderer) drawDebugOverlay(screen ebiten.FinalScreen) {
+	text := formatRegisters(readRegisters(r.chip8))
+
+	lines := strings.Split(text, "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line))
+	}
+	w, h := width*debugCharWidth+2*debugMargin, len(lines)*debugCharHeight+2*debugMargin
+	if r.debugHud == nil || r.debugHud.Bounds().Dx() != w || r.debugHud.Bounds().Dy() != h {
+		r.debugHud = ebiten.NewImage(w, h)
+	}
+	r.debugHud.Fill(overlayColor)
+	ebitenutil.DebugPrintAt(r.debugHud, text, debugMargin, debugMargin)
+
+	screen.DrawImage(r.debugHud, nil)
+}
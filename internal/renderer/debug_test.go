@@ -0,0 +1,50 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatRegisters(t *testing.T) {
+	t.Parallel()
+
+	view := registerView{
+		i:          0x0123,
+		pc:         0x0204,
+		sp:         2,
+		delayTimer: 0x3c,
+		soundTimer: 0x05,
+	}
+	for i := range view.v {
+		view.v[i] = uint8(i * 0x11)
+	}
+
+	expected := "V0=00 V1=11 V2=22 V3=33\n" +
+		"V4=44 V5=55 V6=66 V7=77\n" +
+		"V8=88 V9=99 VA=AA VB=BB\n" +
+		"VC=CC VD=DD VE=EE VF=FF\n" +
+		"I=0123 PC=0204 SP=2\n" +
+		"DT=3C ST=05"
+	require.Equal(t, expected, formatRegisters(view))
+}
+
+func TestReadRegisters(t *testing.T) {
+	t.Parallel()
+
+	machine := chip8.NewChip8()
+	machine.LoadRom(chip8.Rom{
+		Data: []byte{
+			0x6a, 0x42, // v[a] = 0x42
+			0xa3, 0x00, // vI = 0x300
+		},
+	})
+	machine.Step()
+	machine.Step()
+
+	view := readRegisters(&machine)
+	require.Equal(t, uint8(0x42), view.v[0xa])
+	require.Equal(t, uint16(0x300), view.i)
+	require.Equal(t, uint16(0x204), view.pc)
+}
@@ -0,0 +1,46 @@
+package renderer
+
+// flickerFilter hides the blank frame that games redrawing everything with a clear screen show
+// between the erase and the redraw. When a frame gets fully cleared, the previous frame is merged
+// into it for one frame, so the redraw is presented right over the old picture.
+type flickerFilter struct {
+	// the last presented frame that wasn't replaced by a merge
+	prev []bool
+	// the previous frame was merged, the next blank one is presented as is
+	merged bool
+
+	out []bool
+}
+
+func newFlickerFilter(pixels int) flickerFilter {
+	return flickerFilter{
+		prev: make([]bool, pixels),
+		out:  make([]bool, pixels),
+	}
+}
+
+// present returns the frame to show instead of the emulated one.
+// The returned slice is reused by the next call.
+func (f *flickerFilter) present(frame []bool) []bool {
+	if !f.merged && isBlank(frame) && !isBlank(f.prev) {
+		f.merged = true
+		for i := range f.out {
+			f.out[i] = f.prev[i] || frame[i]
+		}
+		return f.out
+	}
+
+	f.merged = false
+	copy(f.prev, frame)
+	copy(f.out, frame)
+	return f.out
+}
+
+func isBlank(frame []bool) bool {
+	for _, on := range frame {
+		if on {
+			return false
+		}
+	}
+	return true
+}
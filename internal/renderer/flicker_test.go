@@ -0,0 +1,47 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlickerFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("draw clear draw", func(t *testing.T) {
+		filter := newFlickerFilter(3)
+
+		first := []bool{true, false, false}
+		require.Equal(t, first, filter.present(first))
+
+		// the clear is merged with the previous frame
+		require.Equal(t, first, filter.present([]bool{false, false, false}))
+
+		// the redraw is shown as is
+		second := []bool{false, true, false}
+		require.Equal(t, second, filter.present(second))
+	})
+
+	t.Run("clear is merged for one frame only", func(t *testing.T) {
+		filter := newFlickerFilter(3)
+
+		blank := []bool{false, false, false}
+		filter.present([]bool{true, true, false})
+		require.Equal(t, []bool{true, true, false}, filter.present(blank))
+		require.Equal(t, blank, filter.present(blank))
+		require.Equal(t, blank, filter.present(blank))
+	})
+
+	t.Run("partial erase isn't merged", func(t *testing.T) {
+		filter := newFlickerFilter(3)
+
+		filter.present([]bool{true, true, false})
+		require.Equal(t, []bool{false, true, false}, filter.present([]bool{false, true, false}))
+	})
+
+	t.Run("blank on start", func(t *testing.T) {
+		filter := newFlickerFilter(3)
+		require.Equal(t, []bool{false, false, false}, filter.present([]bool{false, false, false}))
+	})
+}
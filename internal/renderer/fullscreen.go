@@ -0,0 +1,25 @@
+package renderer
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Window is the part of the window that is switched to fullscreen and back.
+type Window interface {
+	IsFullscreen() bool
+	SetFullscreen(fullscreen bool)
+}
+
+// ebitenWindow is the window of the running game
+type ebitenWindow struct{}
+
+func (ebitenWindow) IsFullscreen() bool {
+	return ebiten.IsFullscreen()
+}
+
+func (ebitenWindow) SetFullscreen(fullscreen bool) {
+	ebiten.SetFullscreen(fullscreen)
+}
+
+// ToggleFullscreen switches the window to fullscreen or back. The screen keeps its aspect ratio, see Layout.
+func (r *Renderer) ToggleFullscreen() {
+	r.window.SetFullscreen(!r.window.IsFullscreen())
+}
@@ -0,0 +1,27 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWindow struct {
+	fullscreen bool
+}
+
+func (w *fakeWindow) IsFullscreen() bool            { return w.fullscreen }
+func (w *fakeWindow) SetFullscreen(fullscreen bool) { w.fullscreen = fullscreen }
+
+func TestRenderer_ToggleFullscreen(t *testing.T) {
+	t.Parallel()
+
+	window := &fakeWindow{}
+	r := &Renderer{window: window}
+
+	r.ToggleFullscreen()
+	require.True(t, window.fullscreen)
+
+	r.ToggleFullscreen()
+	require.False(t, window.fullscreen)
+}
@@ -0,0 +1,79 @@
+package renderer
+
+import (
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// GamepadMapping maps the buttons of gamepads with the standard layout to CHIP8 keys.
+type GamepadMapping struct {
+	// Keys are the CHIP8 keys the buttons press. Buttons that aren't mapped are ignored
+	Keys map[ebiten.StandardGamepadButton]uint8
+}
+
+// DefaultGamepadMapping moves with the d-pad like 2, 4, 6, and 8 on the keypad, which most games use,
+// and fires with the bottom face button like 5. The other buttons cover the rest of the keys.
+var DefaultGamepadMapping = GamepadMapping{
+	Keys: map[ebiten.StandardGamepadButton]uint8{
+		ebiten.StandardGamepadButtonLeftTop:    0x2,
+		ebiten.StandardGamepadButtonLeftLeft:   0x4,
+		ebiten.StandardGamepadButtonLeftRight:  0x6,
+		ebiten.StandardGamepadButtonLeftBottom: 0x8,
+
+		ebiten.StandardGamepadButtonRightBottom: 0x5,
+		ebiten.StandardGamepadButtonRightRight:  0x0,
+		ebiten.StandardGamepadButtonRightLeft:   0x7,
+		ebiten.StandardGamepadButtonRightTop:    0x9,
+
+		ebiten.StandardGamepadButtonFrontTopLeft:     0x1,
+		ebiten.StandardGamepadButtonFrontTopRight:    0x3,
+		ebiten.StandardGamepadButtonFrontBottomLeft:  0xA,
+		ebiten.StandardGamepadButtonFrontBottomRight: 0xB,
+
+		ebiten.StandardGamepadButtonCenterLeft:  0xC,
+		ebiten.StandardGamepadButtonCenterRight: 0xD,
+		ebiten.StandardGamepadButtonLeftStick:   0xE,
+		ebiten.StandardGamepadButtonRightStick:  0xF,
+	},
+}
+
+// PressedKeys returns the CHIP8 keys pressed by the buttons that isPressed reports,
+// the keys out of the keypad are ignored.
+func (m GamepadMapping) PressedKeys(isPressed func(button ebiten.StandardGamepadButton) bool) [keypadKeys]bool {
+	var keys [keypadKeys]bool
+	for button, key := range m.Keys {
+		if int(key) < keypadKeys && isPressed(button) {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// gamepadKeys returns the CHIP8 keys pressed on any of the connected gamepads.
+// A gamepad is used from the update it's connected on, the keys of a disconnected one are released.
+func (r *Renderer) gamepadKeys() [keypadKeys]bool {
+	for _, id := range inpututil.AppendJustConnectedGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			log.Printf("gamepad %s is connected, but it has no standard layout and isn't used\n", ebiten.GamepadName(id))
+			continue
+		}
+		log.Printf("gamepad %s is connected\n", ebiten.GamepadName(id))
+	}
+
+	var keys [keypadKeys]bool
+	r.gamepadIDs = ebiten.AppendGamepadIDs(r.gamepadIDs[:0])
+	for _, id := range r.gamepadIDs {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		pressed := r.gamepadMapping.PressedKeys(func(button ebiten.StandardGamepadButton) bool {
+			return ebiten.IsStandardGamepadButtonPressed(id, button)
+		})
+		for key := range keys {
+			keys[key] = keys[key] || pressed[key]
+		}
+	}
+	return keys
+}
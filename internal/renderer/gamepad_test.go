@@ -0,0 +1,63 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGamepadMapping_PressedKeys(t *testing.T) {
+	t.Parallel()
+
+	pressed := func(buttons ...ebiten.StandardGamepadButton) func(ebiten.StandardGamepadButton) bool {
+		return func(button ebiten.StandardGamepadButton) bool {
+			for _, b := range buttons {
+				if b == button {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	t.Run("default mapping", func(t *testing.T) {
+		keys := DefaultGamepadMapping.PressedKeys(pressed(
+			ebiten.StandardGamepadButtonLeftLeft,
+			ebiten.StandardGamepadButtonRightBottom,
+		))
+
+		var expected [keypadKeys]bool
+		expected[0x4] = true
+		expected[0x5] = true
+		require.Equal(t, expected, keys)
+	})
+
+	t.Run("covers the keypad", func(t *testing.T) {
+		keys := DefaultGamepadMapping.PressedKeys(func(ebiten.StandardGamepadButton) bool { return true })
+		for key, on := range keys {
+			require.True(t, on, "key %X", key)
+		}
+	})
+
+	t.Run("custom mapping", func(t *testing.T) {
+		mapping := GamepadMapping{
+			Keys: map[ebiten.StandardGamepadButton]uint8{
+				ebiten.StandardGamepadButtonRightBottom: 0xF,
+				ebiten.StandardGamepadButtonRightRight:  0xF,
+				// out of the keypad
+				ebiten.StandardGamepadButtonRightTop: 0x10,
+			},
+		}
+
+		keys := mapping.PressedKeys(pressed(ebiten.StandardGamepadButtonRightRight, ebiten.StandardGamepadButtonRightTop))
+
+		var expected [keypadKeys]bool
+		expected[0xF] = true
+		require.Equal(t, expected, keys)
+	})
+
+	t.Run("nothing pressed", func(t *testing.T) {
+		require.Equal(t, [keypadKeys]bool{}, DefaultGamepadMapping.PressedKeys(pressed()))
+	})
+}
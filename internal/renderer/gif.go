@@ -0,0 +1,153 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultGifFPS        = 20
+	defaultGifMaxSeconds = 30
+)
+
+var ErrNoGifFrames = errors.New("no frames are recorded")
+
+// GifRecorder accumulates screen frames of gameplay and encodes them as an animated GIF.
+// Frames are sampled at the fps, the ones in between are dropped.
+// The recording is bounded by maxFrames, so a long one doesn't exhaust memory.
+type GifRecorder struct {
+	palette color.Palette
+	scale   int
+
+	// the size of the recording, it's the size of the first frame
+	width, height int
+
+	interval  time.Duration
+	lastFrame time.Time
+	maxFrames int
+
+	frames *gif.GIF
+}
+
+// NewGifRecorder returns a recorder that paints set pixels with fg and the others with bg.
+// Every pixel is scaled to a square of scale size. The values less than 1 are treated as 1.
+func NewGifRecorder(fg, bg color.Color, scale, fps, maxFrames int) *GifRecorder {
+	return &GifRecorder{
+		palette:   color.Palette{bg, fg},
+		scale:     max(scale, 1),
+		interval:  time.Second / time.Duration(max(fps, 1)),
+		maxFrames: max(maxFrames, 1),
+		frames:    &gif.GIF{},
+	}
+}
+
+// AddFrame records the screen of the size drawn at the moment unless the previous frame
+// was recorded less than the sample interval ago. Frames of another resolution, e.g. after a hires switch,
+// are scaled to the size of the first one. It reports whether the recording has room for more frames.
+func (g *GifRecorder) AddFrame(at time.Time, screen []bool, width, height int) bool {
+	if g.Full() {
+		return false
+	}
+	if len(g.frames.Image) > 0 && at.Sub(g.lastFrame) < g.interval {
+		return true
+	}
+	if len(g.frames.Image) == 0 {
+		g.width, g.height = width, height
+	}
+	g.lastFrame = at
+
+	w, h := g.width*g.scale, g.height*g.scale
+	img := image.NewPaletted(image.Rect(0, 0, w, h), g.palette)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if screen[(y*height/h)*width+x*width/w] {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	g.frames.Image = append(g.frames.Image, img)
+	// the delay is in 100ths of a second
+	g.frames.Delay = append(g.frames.Delay, max(int(g.interval/(10*time.Millisecond)), 1))
+
+	return !g.Full()
+}
+
+// Full reports whether the recording reached the frame limit.
+func (g *GifRecorder) Full() bool {
+	return len(g.frames.Image) >= g.maxFrames
+}
+
+// NumFrames returns the number of recorded frames.
+func (g *GifRecorder) NumFrames() int {
+	return len(g.frames.Image)
+}
+
+// Encode writes the recorded frames as a looped animated GIF.
+func (g *GifRecorder) Encode(w io.Writer) error {
+	if len(g.frames.Image) == 0 {
+		return ErrNoGifFrames
+	}
+	if err := gif.EncodeAll(w, g.frames); err != nil {
+		return fmt.Errorf("couldn't encode the gif: %w", err)
+	}
+	return nil
+}
+
+// toggleGifRecording starts a recording or stops the running one and writes it to the screenshot directory.
+func (r *Renderer) toggleGifRecording() {
+	if r.gif == nil {
+		r.gif = NewGifRecorder(r.palette.Color(1), r.palette.Color(0), r.screenshotScale, r.gifFPS, r.gifFPS*r.gifMaxSeconds)
+		log.Println("gif recording started")
+		r.setWindowTitle()
+		return
+	}
+	r.stopGifRecording()
+}
+
+// recordGifFrame adds the presented frame to the running recording, the recording is stopped once it's full.
+func (r *Renderer) recordGifFrame(frame []bool) {
+	if r.gif == nil {
+		return
+	}
+	if !r.gif.AddFrame(time.Now(), frame, r.chip8.ScreenWidth(), r.chip8.ScreenHeight()) {
+		r.stopGifRecording()
+	}
+}
+
+func (r *Renderer) stopGifRecording() {
+	recorder := r.gif
+	r.gif = nil
+	r.setWindowTitle()
+
+	path := filepath.Join(r.screenshotDir, captureName(r.chip8.GetRomName(), time.Now(), ".gif"))
+	if err := writeGif(path, recorder); err != nil {
+		log.Println(err.Error())
+		return
+	}
+	log.Printf("gif of %d frames saved to %s\n", recorder.NumFrames(), path)
+}
+
+func writeGif(path string, recorder *GifRecorder) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("couldn't create the gif file: %w", err)
+	}
+
+	err = recorder.Encode(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't write the gif: %w", err)
+	}
+	return nil
+}
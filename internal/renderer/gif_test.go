@@ -0,0 +1,88 @@
+package renderer
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGifRecorder(t *testing.T) {
+	t.Parallel()
+
+	fgColor := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	bgColor := color.RGBA{A: 0xff}
+	const width, height, scale = 4, 2, 2
+
+	// a pixel moves right along the top row
+	frameWithPixel := func(x int) []bool {
+		frame := make([]bool, width*height)
+		frame[x] = true
+		return frame
+	}
+
+	t.Run("multi-frame gif", func(t *testing.T) {
+		recorder := NewGifRecorder(fgColor, bgColor, scale, 10, 100)
+
+		now := time.Unix(0, 0)
+		for x := 0; x < width; x++ {
+			require.True(t, recorder.AddFrame(now, frameWithPixel(x), width, height))
+			// a frame drawn before the sample interval passes is dropped
+			require.True(t, recorder.AddFrame(now.Add(50*time.Millisecond), frameWithPixel(0), width, height))
+			now = now.Add(100 * time.Millisecond)
+		}
+		require.Equal(t, width, recorder.NumFrames())
+
+		var buf bytes.Buffer
+		require.NoError(t, recorder.Encode(&buf))
+
+		img, err := gif.DecodeAll(&buf)
+		require.NoError(t, err)
+		require.Len(t, img.Image, width)
+		require.Equal(t, []int{10, 10, 10, 10}, img.Delay)
+
+		for x, frame := range img.Image {
+			require.Equal(t, width*scale, frame.Bounds().Dx())
+			require.Equal(t, height*scale, frame.Bounds().Dy())
+			require.Equal(t, fgColor, color.RGBAModel.Convert(frame.At(x*scale, 0)), "frame %d", x)
+			require.Equal(t, bgColor, color.RGBAModel.Convert(frame.At(x*scale, scale)), "frame %d", x)
+		}
+	})
+
+	t.Run("bounded by max frames", func(t *testing.T) {
+		recorder := NewGifRecorder(fgColor, bgColor, scale, 10, 2)
+
+		now := time.Unix(0, 0)
+		require.True(t, recorder.AddFrame(now, frameWithPixel(0), width, height))
+		require.False(t, recorder.AddFrame(now.Add(time.Second), frameWithPixel(1), width, height))
+		require.False(t, recorder.AddFrame(now.Add(2*time.Second), frameWithPixel(2), width, height))
+
+		require.True(t, recorder.Full())
+		require.Equal(t, 2, recorder.NumFrames())
+	})
+
+	t.Run("frames of another resolution are scaled", func(t *testing.T) {
+		recorder := NewGifRecorder(fgColor, bgColor, 1, 10, 10)
+
+		now := time.Unix(0, 0)
+		recorder.AddFrame(now, make([]bool, width*height), width, height)
+		hires := make([]bool, 2*width*2*height)
+		hires[0] = true
+		recorder.AddFrame(now.Add(time.Second), hires, 2*width, 2*height)
+
+		var buf bytes.Buffer
+		require.NoError(t, recorder.Encode(&buf))
+		img, err := gif.DecodeAll(&buf)
+		require.NoError(t, err)
+		require.Equal(t, width, img.Image[1].Bounds().Dx())
+		require.Equal(t, fgColor, color.RGBAModel.Convert(img.Image[1].At(0, 0)))
+	})
+
+	t.Run("nothing recorded", func(t *testing.T) {
+		recorder := NewGifRecorder(fgColor, bgColor, scale, 10, 10)
+		require.ErrorIs(t, recorder.Encode(&bytes.Buffer{}), ErrNoGifFrames)
+	})
+}
@@ -0,0 +1,60 @@
+package renderer
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SetKeyMap remaps the keyboard keys of CHIP8 keys, e.g. for non-QWERTY layouts.
+// The CHIP8 keys that aren't in the map keep their default keyboard keys, the ones above F are ignored.
+func (r *Renderer) SetKeyMap(keyMap map[uint8]ebiten.Key) {
+	r.keyMap = mergeKeyMap(keyMap)
+}
+
+// mergeKeyMap returns the default keyboard mapping overridden by the custom one.
+func mergeKeyMap(custom map[uint8]ebiten.Key) map[uint8]ebiten.Key {
+	keyMap := maps.Clone(keyboardMapping)
+	for chip8Key, ebitenKey := range custom {
+		if int(chip8Key) < keypadKeys {
+			keyMap[chip8Key] = ebitenKey
+		}
+	}
+	return keyMap
+}
+
+// updateKeys presses the CHIP8 keys whose keyboard keys are pressed or that are pressed on a gamepad.
+func (r *Renderer) updateKeys(padKeys [keypadKeys]bool) {
+	for chip8Key, ebitenKey := range r.keyMap {
+		if err := r.chip8.SetKey(chip8Key, r.isKeyPressed(ebitenKey) || padKeys[chip8Key]); err != nil {
+			log.Println(err.Error())
+		}
+	}
+}
+
+// ParseKeyMap parses comma separated CHIP8 keys and the names of their keyboard keys, e.g. "4=A,7=Q".
+// The CHIP8 keys are hex digits, the keyboard key names are the ones of ebiten.Key.
+func ParseKeyMap(s string) (map[uint8]ebiten.Key, error) {
+	keyMap := make(map[uint8]ebiten.Key)
+	for _, pair := range strings.Split(s, ",") {
+		chip8Key, name, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("key mapping %s isn't chip8key=key", pair)
+		}
+
+		k, err := strconv.ParseUint(chip8Key, 16, 8)
+		if err != nil || k >= keypadKeys {
+			return nil, fmt.Errorf("chip8 key %s is invalid, must be a hex digit", chip8Key)
+		}
+		var ebitenKey ebiten.Key
+		if err := ebitenKey.UnmarshalText([]byte(name)); err != nil {
+			return nil, fmt.Errorf("keyboard key %s is invalid: %w", name, err)
+		}
+		keyMap[uint8(k)] = ebitenKey
+	}
+	return keyMap, nil
+}
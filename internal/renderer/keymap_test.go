@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_KeyMap(t *testing.T) {
+	t.Parallel()
+
+	newRenderer := func(keyMap map[uint8]ebiten.Key, pressed ...ebiten.Key) (*Renderer, *chip8.Chip8, map[ebiten.Key]bool) {
+		machine := chip8.NewChip8()
+		r := NewFromConfig(&machine, Config{KeyMap: keyMap})
+
+		queried := make(map[ebiten.Key]bool)
+		r.isKeyPressed = func(key ebiten.Key) bool {
+			queried[key] = true
+			for _, p := range pressed {
+				if p == key {
+					return true
+				}
+			}
+			return false
+		}
+		return r, &machine, queried
+	}
+
+	t.Run("remapped keys", func(t *testing.T) {
+		// AZERTY puts A and Q in place of Q and A
+		keyMap := map[uint8]ebiten.Key{0x4: ebiten.KeyA, 0x7: ebiten.KeyQ}
+		r, machine, queried := newRenderer(keyMap, ebiten.KeyA)
+
+		r.updateKeys([keypadKeys]bool{})
+
+		require.True(t, machine.KeyIsPressed(0x4))
+		require.False(t, machine.KeyIsPressed(0x7))
+		require.Len(t, queried, keypadKeys)
+	})
+
+	t.Run("missing keys keep the defaults", func(t *testing.T) {
+		r, machine, queried := newRenderer(map[uint8]ebiten.Key{0x5: ebiten.KeyJ, 0x10: ebiten.KeyK}, ebiten.KeyJ, ebiten.KeyE)
+
+		r.updateKeys([keypadKeys]bool{})
+
+		require.True(t, machine.KeyIsPressed(0x5))
+		require.True(t, machine.KeyIsPressed(0x6), "E is the default of 6")
+		require.False(t, queried[ebiten.KeyW], "W isn't the key of 5 anymore")
+		require.False(t, queried[ebiten.KeyK], "keys above F are ignored")
+	})
+
+	t.Run("set at run time", func(t *testing.T) {
+		r, machine, _ := newRenderer(nil, ebiten.KeyJ)
+		r.SetKeyMap(map[uint8]ebiten.Key{0xF: ebiten.KeyJ})
+
+		r.updateKeys([keypadKeys]bool{})
+
+		require.True(t, machine.KeyIsPressed(0xF))
+	})
+
+	t.Run("gamepad keys", func(t *testing.T) {
+		r, machine, _ := newRenderer(nil)
+
+		var padKeys [keypadKeys]bool
+		padKeys[0x2] = true
+		r.updateKeys(padKeys)
+
+		require.True(t, machine.KeyIsPressed(0x2))
+	})
+}
+
+func TestParseKeyMap(t *testing.T) {
+	t.Parallel()
+
+	keyMap, err := ParseKeyMap("4=A, 7=Q,f=Digit0")
+	require.NoError(t, err)
+	require.Equal(t, map[uint8]ebiten.Key{0x4: ebiten.KeyA, 0x7: ebiten.KeyQ, 0xF: ebiten.Key0}, keyMap)
+
+	for _, s := range []string{"4", "G=A", "10=A", "4=Nope"} {
+		_, err := ParseKeyMap(s)
+		require.Error(t, err, s)
+	}
+}
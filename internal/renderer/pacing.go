@@ -0,0 +1,19 @@
+package renderer
+
+import "github.com/nevisdale/go-chip8/internal/chip8"
+
+// frameBudget splits the instruction rate into the instructions of the 60 Hz updates.
+// The rate set by SetCyclesPerFrame runs exactly CyclesPerFrame instructions per update. The remainder of other rates,
+// e.g. of the 500 ips of a max ips, is carried to the next updates, so a second of updates executes the whole rate.
+type frameBudget struct {
+	// instructions per second carried from the previous updates, less than an instruction per update
+	pending int
+}
+
+// next returns the number of instructions of the next update at the rate.
+func (b *frameBudget) next(rate int) int {
+	b.pending += max(rate, 0)
+	n := b.pending / chip8.FramesPerSecond
+	b.pending -= n * chip8.FramesPerSecond
+	return n
+}
@@ -0,0 +1,125 @@
+package renderer
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameBudget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cycles per frame", func(t *testing.T) {
+		var budget frameBudget
+
+		for i := 0; i < 10; i++ {
+			require.Equal(t, 12, budget.next(12*chip8.FramesPerSecond))
+		}
+	})
+
+	t.Run("remainder is carried", func(t *testing.T) {
+		var budget frameBudget
+
+		// 500 ips is 8.33 instructions per frame
+		require.Equal(t, []int{8, 8, 9}, []int{budget.next(500), budget.next(500), budget.next(500)})
+
+		total := 0
+		for i := 0; i < chip8.FramesPerSecond; i++ {
+			total += budget.next(500)
+		}
+		require.Equal(t, 500, total, "a second of updates")
+	})
+
+	t.Run("slow rate", func(t *testing.T) {
+		var budget frameBudget
+
+		total := 0
+		for i := 0; i < chip8.FramesPerSecond; i++ {
+			n := budget.next(10)
+			require.LessOrEqual(t, n, 1)
+			total += n
+		}
+		require.Equal(t, 10, total)
+	})
+}
+
+func TestRenderer_EmulateFrame(t *testing.T) {
+	t.Parallel()
+
+	newMachine := func() *chip8.Chip8 {
+		machine := chip8.NewChip8()
+		machine.LoadRom(chip8.Rom{
+			Data: []byte{
+				0x70, 0x01, // 0x200: v[0] += 1
+				0x12, 0x00, // 0x202: jump to 0x200
+			},
+		})
+		return &machine
+	}
+
+	t.Run("cycles per frame", func(t *testing.T) {
+		machine := newMachine()
+		require.NoError(t, machine.SetCyclesPerFrame(15))
+		r := NewFromConfig(machine, Config{})
+
+		r.emulateFrame()
+		require.Equal(t, uint64(15), machine.InstructionCount())
+		r.emulateFrame()
+		require.Equal(t, uint64(30), machine.InstructionCount())
+		require.Equal(t, uint64(2), machine.FrameCount(), "a machine frame per update")
+	})
+
+	t.Run("max ips", func(t *testing.T) {
+		machine := newMachine()
+		require.NoError(t, machine.SetCyclesPerFrame(30))
+		machine.SetMaxIPS(600)
+		r := NewFromConfig(machine, Config{})
+
+		r.emulateFrame()
+		require.Equal(t, uint64(10), machine.InstructionCount())
+	})
+
+	t.Run("turbo", func(t *testing.T) {
+		machine := newMachine()
+		require.NoError(t, machine.SetCyclesPerFrame(10))
+		r := NewFromConfig(machine, Config{TurboMultiplier: 3})
+		r.turbo.update(true)
+
+		r.emulateFrame()
+		require.Equal(t, uint64(30), machine.InstructionCount())
+	})
+
+	t.Run("background", func(t *testing.T) {
+		machine := newMachine()
+		r := NewFromConfig(machine, Config{Background: true})
+
+		// the background run executes the instructions at the tps, see chip8.Run
+		r.emulateFrame()
+		require.Equal(t, uint64(0), machine.InstructionCount())
+	})
+
+	t.Run("ends the frame", func(t *testing.T) {
+		machine := chip8.NewChip8()
+		machine.LoadRom(chip8.Rom{
+			Data: []byte{
+				0xa0, 0x00, // 0x200: vI = the font of 0
+				0xd0, 0x05, // 0x202: draw(v[0], v[0], 5)
+				0x00, 0xe0, // 0x204: clear the screen
+				0x12, 0x06, // 0x206: jump to 0x206
+			},
+		})
+		require.NoError(t, machine.SetCyclesPerFrame(2))
+		r := NewFromConfig(&machine, Config{})
+		white, black := color.White, color.Black
+
+		r.emulateFrame()
+		drawn := machine.CaptureImage(white, black, 1)
+
+		// the screen cleared in the middle of the next frame isn't presented yet
+		require.NoError(t, machine.Emulate())
+		require.NotEqual(t, drawn, machine.CaptureImage(white, black, 1))
+		require.Equal(t, drawn, machine.CaptureFrameImage(white, black, 1))
+	})
+}
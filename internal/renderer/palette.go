@@ -0,0 +1,30 @@
+package renderer
+
+import "image/color"
+
+// Palette maps pixel values to colors. The value 0 is the background.
+// A single plane screen uses the first 2 colors, XO-CHIP planes combine into the first 4.
+type Palette []color.Color
+
+// black background with white pixels
+var defaultPalette = Palette{
+	color.RGBA{A: 0xff},
+	color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+}
+
+// Color returns the color of the pixel value.
+// Values without a color of their own get the last color of the palette.
+func (p Palette) Color(value int) color.Color {
+	if value < 0 {
+		value = 0
+	}
+	return p[min(value, len(p)-1)]
+}
+
+// pixelValue converts a single plane pixel to its palette index
+func pixelValue(on bool) int {
+	if on {
+		return 1
+	}
+	return 0
+}
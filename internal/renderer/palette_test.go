@@ -0,0 +1,40 @@
+package renderer
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPalette_Color(t *testing.T) {
+	t.Parallel()
+
+	black := color.RGBA{A: 0xff}
+	red := color.RGBA{R: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	t.Run("4 colors", func(t *testing.T) {
+		palette := Palette{black, red, green, blue}
+
+		require.Equal(t, black, palette.Color(0))
+		require.Equal(t, red, palette.Color(1))
+		require.Equal(t, green, palette.Color(2))
+		require.Equal(t, blue, palette.Color(3))
+	})
+
+	t.Run("single plane pixels", func(t *testing.T) {
+		palette := Palette{black, red}
+
+		require.Equal(t, black, palette.Color(pixelValue(false)))
+		require.Equal(t, red, palette.Color(pixelValue(true)))
+	})
+
+	t.Run("value out of palette", func(t *testing.T) {
+		palette := Palette{black, red}
+
+		require.Equal(t, red, palette.Color(3))
+		require.Equal(t, black, palette.Color(-1))
+	})
+}
@@ -1,12 +1,17 @@
 package renderer
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"image/color"
 	"log"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/nevisdale/go-chip8/internal/chip8"
@@ -34,48 +39,284 @@ var keyboardPosition = map[uint8]uint8{
 	0xC: 0xA, 0xD: 0x0, 0xE: 0xB, 0xF: 0xF,
 }
 
+const (
+	// keys of the CHIP8 keypad
+	keypadKeys         = 16
+	keypadButtonsInRow = 4
+	keypadButtonSize   = 4
+	keypadSize         = keypadButtonsInRow*keypadButtonSize + keypadButtonsInRow - 1
+	// keypad with margins around it
+	keypadAreaSize = keypadSize + 3
+)
+
+// KeypadPlacement is where the keypad window is drawn relative to the CHIP8 screen
+type KeypadPlacement int
+
+const (
+	KeypadBelow KeypadPlacement = iota
+	KeypadRight
+)
+
 var (
 	buttonReleasedColor color.Color = MustDecodeColorFromHex("999999")
 	buttonPressedColor  color.Color = MustDecodeColorFromHex("65f057")
+	overlayColor        color.Color = MustDecodeColorFromHex("000000cc")
 )
 
+// the window is 10 times the screen resolution by default
+const defaultScale = 10
+
 type Config struct {
-	FgColor color.Color
-	BgColor color.Color
+	// Palette colors the pixels, the first color is the background.
+	// The default palette is used if it has less than 2 colors
+	Palette Palette
+
+	KeypadPlacement KeypadPlacement
+
+	// KeyMap maps CHIP8 keys to keyboard keys, the missing ones keep the default keys, see SetKeyMap
+	KeyMap map[uint8]ebiten.Key
+
+	// GamepadMapping maps gamepad buttons to CHIP8 keys. nil Keys means DefaultGamepadMapping
+	GamepadMapping GamepadMapping
+
+	// Fullscreen starts the window in fullscreen, F11 switches it back
+	Fullscreen bool
+
+	// Scale is the initial window size in window pixels per screen pixel. 0 means the default scale.
+	// The screen keeps its logical resolution, so the pixels stay square
+	Scale int
+
+	// IdleTPS is the update rate while the machine is paused or jumps to itself with the timers stopped.
+	// 0 disables throttling
+	IdleTPS int
+
+	// TurboMultiplier is how many times faster the emulation runs while Tab is held. 0 means the default multiplier
+	TurboMultiplier int
+
+	// GhostTrailFrames is how many frames an erased pixel keeps fading out. 0 disables the trail
+	GhostTrailFrames int
+
+	// FlickerReduction shows the previous frame instead of a blank one
+	// when a game clears the screen before redrawing it
+	FlickerReduction bool
+
+	// WatchRomPath is the rom file to reload and restart when it changes on disk. empty disables watching
+	WatchRomPath string
+
+	// ScreenshotDir is the directory the F2 screenshots are written to. empty is the working directory
+	ScreenshotDir string
+	// ScreenshotScale is the size of the square a pixel is scaled to in screenshots and gifs. 0 means the default scale
+	ScreenshotScale int
+
+	// Background runs the emulation in a goroutine at the instruction rate instead of
+	// CyclesPerFrame instructions per update, see chip8.Run
+	Background bool
+
+	// GifFPS is the frame rate of the gifs recorded with F4. 0 means the default rate
+	GifFPS int
+	// GifMaxSeconds limits the length of a gif, the recording stops when it's reached. 0 means the default limit
+	GifMaxSeconds int
 }
 
 type Renderer struct {
 	chip8 *chip8.Chip8
+	// guards the machine, ebiten and the background emulation use it from different goroutines
+	mu sync.Mutex
+	// the machine runs itself, see Config.Background
+	background bool
 
-	fgColor color.Color
-	bgColor color.Color
+	palette Palette
 
-	keypadMode bool
+	keypadMode      bool
+	keypadPlacement KeypadPlacement
+
+	keyMap       map[uint8]ebiten.Key
+	isKeyPressed func(key ebiten.Key) bool
+
+	gamepadMapping GamepadMapping
+	// the buffer of connected gamepads
+	gamepadIDs []ebiten.GamepadID
+
+	scale      int
+	window     Window
+	fullscreen bool
+
+	budget  frameBudget
+	turbo   turbo
+	idleTPS int
+	// the halt error is reported once
+	haltReported bool
+
+	clipboard Clipboard
+
+	screenshotDir   string
+	screenshotScale int
+
+	// nil if no gif is being recorded
+	gif           *GifRecorder
+	gifFPS        int
+	gifMaxSeconds int
+
+	// nil if the rom file isn't watched
+	watcher *romWatcher
+
+	trail ghostTrail
+
+	flickerReduction bool
+	flicker          flickerFilter
+	frame            []bool
+
+	// compatibility warnings are shown once on rom start
+	// and the emulation waits until any key is pressed
+	warnings []string
+	// text is drawn on the final screen, because the logical one is too small for it
+	hud *ebiten.Image
+
+	// the registers are shown over the game
+	debugOverlay bool
+	debugHud     *ebiten.Image
 }
 
 func NewFromConfig(chip8 *chip8.Chip8, conf Config) *Renderer {
+	palette := conf.Palette
+	if len(palette) < 2 {
+		palette = defaultPalette
+	}
+
+	gamepadMapping := conf.GamepadMapping
+	if gamepadMapping.Keys == nil {
+		gamepadMapping = DefaultGamepadMapping
+	}
+
+	scale := conf.Scale
+	if scale < 1 {
+		scale = defaultScale
+	}
+
+	screenshotScale := conf.ScreenshotScale
+	if screenshotScale < 1 {
+		screenshotScale = defaultScreenshotScale
+	}
+
+	gifFPS := conf.GifFPS
+	if gifFPS < 1 {
+		gifFPS = defaultGifFPS
+	}
+	gifMaxSeconds := conf.GifMaxSeconds
+	if gifMaxSeconds < 1 {
+		gifMaxSeconds = defaultGifMaxSeconds
+	}
+
+	var watcher *romWatcher
+	if len(conf.WatchRomPath) > 0 {
+		watcher = newRomWatcher(conf.WatchRomPath)
+	}
+
 	return &Renderer{
-		chip8: chip8,
+		chip8:      chip8,
+		background: conf.Background,
+
+		palette: palette,
+
+		keypadPlacement: conf.KeypadPlacement,
+
+		keyMap:       mergeKeyMap(conf.KeyMap),
+		isKeyPressed: ebiten.IsKeyPressed,
 
-		fgColor: conf.FgColor,
-		bgColor: conf.BgColor,
+		gamepadMapping: gamepadMapping,
+
+		scale:      scale,
+		window:     ebitenWindow{},
+		fullscreen: conf.Fullscreen,
+
+		turbo:   newTurbo(conf.TurboMultiplier),
+		idleTPS: conf.IdleTPS,
+
+		clipboard: &systemClipboard{},
+		watcher:   watcher,
+
+		screenshotDir:   conf.ScreenshotDir,
+		screenshotScale: screenshotScale,
+
+		gifFPS:        gifFPS,
+		gifMaxSeconds: gifMaxSeconds,
+
+		trail: newGhostTrail(conf.GhostTrailFrames, chip8.ScreenWidth()*chip8.ScreenHeight()),
+
+		flickerReduction: conf.FlickerReduction,
+		flicker:          newFlickerFilter(chip8.ScreenWidth() * chip8.ScreenHeight()),
+		frame:            make([]bool, chip8.ScreenWidth()*chip8.ScreenHeight()),
+
+		warnings: chip8.GetRom().ScanCompatibility().Warnings(),
 	}
 }
 
 func (r *Renderer) Update() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 		return ebiten.Termination
 	}
 
+	if len(r.warnings) > 0 {
+		if len(inpututil.AppendJustPressedKeys(nil)) > 0 {
+			r.warnings = nil
+		}
+		return nil
+	}
+
 	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
 		r.chip8.TogglePause()
 		r.setWindowTitle()
 	}
 
+	if r.chip8.GetState() == chip8.StatePaused {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyN) && ebiten.IsKeyPressed(ebiten.KeyShift):
+			r.chip8.StepFrame()
+		case inpututil.IsKeyJustPressed(ebiten.KeyArrowRight), inpututil.IsKeyJustPressed(ebiten.KeyN):
+			r.chip8.Step()
+		case inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft):
+			r.chip8.StepBack()
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		if path, err := r.saveTimestampedScreenshot(); err != nil {
+			log.Println(err.Error())
+		} else {
+			log.Printf("screenshot saved to %s\n", path)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
+		if err := r.CopyScreenToClipboard(); err != nil {
+			log.Println(err.Error())
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF4) {
+		r.toggleGifRecording()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		r.ToggleFullscreen()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		r.debugOverlay = !r.debugOverlay
+	}
+
 	if inpututil.IsKeyJustPressed(ebiten.KeyK) {
 		r.keypadMode = !r.keypadMode
 	}
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		r.chip8.ToggleSoundMute()
+		r.setWindowTitle()
+	}
+
 	switch {
 	case inpututil.IsKeyJustPressed(ebiten.Key0):
 		r.chip8.SoundVolumeUp()
@@ -83,54 +324,125 @@ func (r *Renderer) Update() error {
 		r.chip8.SoundVolumeDown()
 	}
 
-	for chip8Key, ebitenKey := range keyboardMapping {
-		r.chip8.SetKey(chip8Key, ebiten.IsKeyPressed(ebitenKey))
+	r.updateKeys(r.gamepadKeys())
+
+	now := time.Now()
+	if r.watcher != nil && r.watcher.changed(now) {
+		r.reloadRom()
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyBackspace) {
+		// rewinding out of a crash resumes the machine, the next halt is reported again
+		if r.chip8.Rewind() && r.haltReported && r.chip8.GetState() != chip8.StateHalted {
+			r.haltReported = false
+			r.setWindowTitle()
+		}
+		r.chip8.EndFrame()
+		return nil
+	}
+
+	if r.turbo.update(ebiten.IsKeyPressed(ebiten.KeyTab)) {
+		r.setWindowTitle()
+	}
+	r.emulateFrame()
+
+	if r.chip8.GetState() == chip8.StateQuit {
+		return ebiten.Termination
+	}
+
+	if r.chip8.GetState() == chip8.StateHalted && !r.haltReported {
+		r.haltReported = true
+		log.Printf("the machine is halted: %s\n", r.chip8.Err())
+		for _, record := range r.chip8.RecentInstructions() {
+			log.Printf("%04X: %04X  %s\n", record.PC, record.Opcode, chip8.DisassembleOpcode(record.Opcode))
+		}
+		r.setWindowTitle()
+	}
+
+	if tps := r.updateTPS(); tps != ebiten.TPS() {
+		ebiten.SetTPS(tps)
 	}
-	r.chip8.Emulate()
 
 	return nil
 }
 
+// updateTPS returns the update rate for the current machine activity, see throttledTPS.
+func (r *Renderer) updateTPS() int {
+	delay, sound := r.chip8.Timers()
+	return throttledTPS(chip8.FramesPerSecond, r.idleTPS, r.chip8.GetState(), r.chip8.IsIdle(), delay > 0 || sound > 0)
+}
+
+// emulateFrame executes the instructions of an update, CyclesPerFrame of them at the tps set by SetCyclesPerFrame.
+// The turbo multiplies them while it's held. The machine running in the background isn't emulated here.
+// The frame ends after the instructions, however many times ebiten draws it.
+func (r *Renderer) emulateFrame() {
+	defer r.chip8.EndFrame()
+
+	if r.background {
+		return
+	}
+
+	instructions := r.turbo.instructions(r.budget.next(r.chip8.InstructionRate()))
+	for i := 0; i < instructions; i++ {
+		// the halt is reported by Update
+		if err := r.chip8.Emulate(); err != nil {
+			break
+		}
+	}
+}
+
 func (r *Renderer) Draw(screen *ebiten.Image) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	// CHIP8 screen
 	chip8ScreenOffsetX := 0
 	chip8ScreenOffsetY := 0
+	r.resizeScreen()
 	for x := 0; x < r.chip8.ScreenWidth(); x++ {
 		for y := 0; y < r.chip8.ScreenHeight(); y++ {
-			pixelColor := r.bgColor
-			if r.chip8.ScreenPixelSetAt(x, y) {
-				pixelColor = r.fgColor
-			}
-
-			screen.Set(chip8ScreenOffsetX+x, chip8ScreenOffsetY+y, pixelColor)
+			r.frame[y*r.chip8.ScreenWidth()+x] = r.chip8.ScreenPixelSetAt(x, y)
 		}
 	}
 
+	frame := r.frame
+	if r.flickerReduction {
+		frame = r.flicker.present(frame)
+	}
+	r.recordGifFrame(frame)
+
+	r.drawScreen(screen, chip8ScreenOffsetX, chip8ScreenOffsetY, frame)
+
 	// Keypad screen
 	if r.keypadMode {
-		buttonsInRow := 4
-		buttonSize := 4
-
-		// center by X
-		screenOffsetX := chip8ScreenOffsetX + (r.chip8.ScreenWidth()-(buttonsInRow*buttonSize+buttonsInRow-1))>>1
-		screenOffsetY := chip8ScreenOffsetY + r.chip8.ScreenHeight() + 1
+		var screenOffsetX, screenOffsetY int
+		switch r.keypadPlacement {
+		case KeypadRight:
+			// center by Y
+			screenOffsetX = chip8ScreenOffsetX + r.chip8.ScreenWidth() + 1
+			screenOffsetY = chip8ScreenOffsetY + (r.chip8.ScreenHeight()-keypadSize)>>1
+		default:
+			// center by X
+			screenOffsetX = chip8ScreenOffsetX + (r.chip8.ScreenWidth()-keypadSize)>>1
+			screenOffsetY = chip8ScreenOffsetY + r.chip8.ScreenHeight() + 1
+		}
 
-		for x := 0; x < 4; x++ {
-			for y := 0; y < 4; y++ {
+		for x := 0; x < keypadButtonsInRow; x++ {
+			for y := 0; y < keypadButtonsInRow; y++ {
 				pixelColor := buttonReleasedColor
 				key := y<<2 | x&0xf
 				if r.chip8.KeyIsPressed(keyboardPosition[uint8(key)]) {
 					pixelColor = buttonPressedColor
 				}
 
-				posX := screenOffsetX + (x * (buttonSize + 1))
-				posY := screenOffsetY + (y * (buttonSize + 1))
+				posX := screenOffsetX + (x * (keypadButtonSize + 1))
+				posY := screenOffsetY + (y * (keypadButtonSize + 1))
 
 				vector.DrawFilledRect(screen,
 					float32(posX),
 					float32(posY),
-					float32(buttonSize),
-					float32(buttonSize),
+					float32(keypadButtonSize),
+					float32(keypadButtonSize),
 					pixelColor, false,
 				)
 			}
@@ -139,27 +451,147 @@ func (r *Renderer) Draw(screen *ebiten.Image) {
 	}
 }
 
+// pixelSetter is the part of an image the screen is drawn to, e.g. *ebiten.Image or *image.RGBA
+type pixelSetter interface {
+	Set(x, y int, c color.Color)
+}
+
+// drawScreen draws the frame at the offset, every pixel is colored by the palette entry of its value.
+// Erased pixels of the ghost trail fade out from the first pixel color to the background.
+func (r *Renderer) drawScreen(dst pixelSetter, offsetX, offsetY int, frame []bool) {
+	width := r.chip8.ScreenWidth()
+	for x := 0; x < width; x++ {
+		for y := 0; y < r.chip8.ScreenHeight(); y++ {
+			pos := y*width + x
+			value := pixelValue(frame[pos])
+			intensity := r.trail.update(pos, value != 0)
+
+			pixelColor := r.palette.Color(value)
+			if value == 0 {
+				// erased pixels fade out to the background
+				pixelColor = blendColor(r.palette.Color(0), r.palette.Color(1), intensity)
+			}
+
+			dst.Set(offsetX+x, offsetY+y, pixelColor)
+		}
+	}
+}
+
+// resizeScreen reallocates the per-pixel state when the machine switches the resolution.
+// The trail and flicker history of the other resolution are dropped.
+func (r *Renderer) resizeScreen() {
+	pixels := r.chip8.ScreenWidth() * r.chip8.ScreenHeight()
+	if len(r.frame) == pixels {
+		return
+	}
+
+	r.frame = make([]bool, pixels)
+	r.trail = newGhostTrail(r.trail.frames, pixels)
+	r.flicker = newFlickerFilter(pixels)
+}
+
+func (r *Renderer) DrawFinalScreen(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	screen.DrawImage(offscreen, &ebiten.DrawImageOptions{GeoM: geoM})
+
+	if len(r.warnings) == 0 {
+		if r.debugOverlay {
+			r.drawDebugOverlay(screen)
+		}
+		return
+	}
+
+	bounds := screen.Bounds()
+	if r.hud == nil || r.hud.Bounds() != bounds {
+		r.hud = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+	r.hud.Fill(overlayColor)
+
+	lines := []string{"The rom uses quirk-sensitive opcodes:"}
+	for _, warning := range r.warnings {
+		lines = append(lines, "  - "+warning)
+	}
+	lines = append(lines,
+		"The game may misbehave with the current interpreter behavior.",
+		"",
+		"Press any key to continue",
+	)
+	ebitenutil.DebugPrintAt(r.hud, strings.Join(lines, "\n"), 8, 8)
+
+	screen.DrawImage(r.hud, nil)
+}
+
 func (r *Renderer) Layout(int, int) (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	w, h := r.chip8.ScreenSize()
 	if r.keypadMode {
-		return w, h + 22
+		switch r.keypadPlacement {
+		case KeypadRight:
+			return w + keypadAreaSize, h
+		default:
+			return w, h + keypadAreaSize
+		}
 	}
 	return w, h
 }
 
 func (r *Renderer) Run() error {
-	ebiten.SetTPS(r.chip8.GetTPS())
+	// an update is a 60 Hz frame of the machine, see emulateFrame
+	ebiten.SetTPS(chip8.FramesPerSecond)
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	w, h := r.Layout(0, 0)
+	ebiten.SetWindowSize(w*r.scale, h*r.scale)
+	if r.fullscreen {
+		r.window.SetFullscreen(true)
+	}
 	r.setWindowTitle()
 
+	if r.background {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go r.chip8.Run(ctx, &r.mu)
+	}
+
 	if err := ebiten.RunGame(r); err != nil {
 		return fmt.Errorf("run renderer: %w", err)
 	}
 	return nil
 }
 
+// reloadRom restarts the machine with the rom from the watched file.
+// The current game goes on if the file can't be loaded.
+func (r *Renderer) reloadRom() {
+	rom, err := chip8.NewRomFromFile(r.watcher.path)
+	if err != nil {
+		log.Printf("couldn't reload the rom: %s\n", err.Error())
+		return
+	}
+	if err := r.chip8.LoadRom(rom); err != nil {
+		log.Printf("couldn't reload the rom: %s\n", err.Error())
+		return
+	}
+
+	r.haltReported = false
+	r.setWindowTitle()
+	log.Printf("the rom %s is reloaded\n", rom.Name)
+}
+
 func (r *Renderer) setWindowTitle() {
-	ebiten.SetWindowTitle("CHIP8 Emulator: " + r.chip8.GetRomName() + " " + r.chip8.GetState().String())
+	title := "CHIP8 Emulator: " + r.chip8.GetRomName() + " " + r.chip8.GetState().String()
+	if r.chip8.IsSoundMuted() {
+		title += " Muted"
+	}
+	if r.gif != nil {
+		title += " Recording"
+	}
+	if r.turbo.held {
+		title += " Turbo"
+	}
+	ebiten.SetWindowTitle(title)
 }
 
 func MustDecodeColorFromHex(s string) color.Color {
@@ -0,0 +1,157 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_Layout(t *testing.T) {
+	t.Parallel()
+
+	machine := chip8.NewChip8()
+	screenWidth, screenHeight := machine.ScreenSize()
+
+	tests := []struct {
+		name           string
+		placement      KeypadPlacement
+		keypadMode     bool
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{"no keypad", KeypadBelow, false, screenWidth, screenHeight},
+		{"keypad below", KeypadBelow, true, screenWidth, screenHeight + 22},
+		{"keypad right", KeypadRight, true, screenWidth + 22, screenHeight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewFromConfig(&machine, Config{KeypadPlacement: tt.placement})
+			r.keypadMode = tt.keypadMode
+
+			w, h := r.Layout(0, 0)
+			require.Equal(t, tt.expectedWidth, w)
+			require.Equal(t, tt.expectedHeight, h)
+		})
+	}
+}
+
+func TestRenderer_LayoutScale(t *testing.T) {
+	t.Parallel()
+
+	machine := chip8.NewChip8()
+	screenWidth, screenHeight := machine.ScreenSize()
+
+	for _, scale := range []int{0, 1, 3, 20} {
+		r := NewFromConfig(&machine, Config{Scale: scale})
+
+		// the logical size is the screen resolution whatever the window size is
+		w, h := r.Layout(screenWidth*scale+7, screenHeight*scale+3)
+		require.Equal(t, screenWidth, w, "scale %d", scale)
+		require.Equal(t, screenHeight, h, "scale %d", scale)
+	}
+
+	require.Equal(t, defaultScale, NewFromConfig(&machine, Config{}).scale)
+}
+
+func TestRenderer_LayoutHires(t *testing.T) {
+	t.Parallel()
+
+	machine := chip8.NewChip8()
+	machine.LoadRom(chip8.Rom{
+		Data: []byte{
+			0x00, 0xff, // high resolution
+		},
+	})
+	r := NewFromConfig(&machine, Config{})
+
+	w, h := r.Layout(0, 0)
+	require.Equal(t, 64, w)
+	require.Equal(t, 32, h)
+
+	machine.Step()
+
+	w, h = r.Layout(0, 0)
+	require.Equal(t, 128, w)
+	require.Equal(t, 64, h)
+}
+
+func TestRenderer_DrawScreen(t *testing.T) {
+	t.Parallel()
+
+	bg := color.RGBA{B: 0x40, A: 0xff}
+	fg := color.RGBA{R: 0xff, G: 0xc0, A: 0xff}
+	// classic roms have no pixels of the extra colors
+	extra := color.RGBA{G: 0xff, A: 0xff}
+
+	machine := chip8.NewChip8()
+	machine.LoadRom(chip8.Rom{
+		Data: []byte{
+			0xa0, 0x00, // vI = 0x000, font sprite of 0
+			0xd0, 0x05, // draw(0, 0, 5)
+		},
+	})
+	machine.Step()
+	machine.Step()
+
+	r := NewFromConfig(&machine, Config{Palette: Palette{bg, fg, extra}})
+	frame := make([]bool, machine.ScreenWidth()*machine.ScreenHeight())
+	for y := 0; y < machine.ScreenHeight(); y++ {
+		for x := 0; x < machine.ScreenWidth(); x++ {
+			frame[y*machine.ScreenWidth()+x] = machine.ScreenPixelSetAt(x, y)
+		}
+	}
+
+	// the screen is drawn with an offset of a pixel
+	img := image.NewRGBA(image.Rect(0, 0, machine.ScreenWidth()+1, machine.ScreenHeight()+1))
+	r.drawScreen(img, 1, 1, frame)
+
+	require.Equal(t, color.RGBA{}, img.RGBAAt(0, 0), "outside of the screen")
+	// the top row of 0 is on, its middle rows are hollow
+	for x := 0; x < 4; x++ {
+		require.Equal(t, fg, img.RGBAAt(1+x, 1), "pixel (%d, 0)", x)
+	}
+	require.Equal(t, bg, img.RGBAAt(2, 2))
+	require.Equal(t, bg, img.RGBAAt(machine.ScreenWidth(), machine.ScreenHeight()))
+
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			require.NotEqual(t, extra, img.RGBAAt(x, y), "pixel (%d, %d)", x, y)
+		}
+	}
+}
+
+func TestRenderer_DrawScreenGhostTrail(t *testing.T) {
+	t.Parallel()
+
+	bg := color.RGBA{A: 0xff}
+	fg := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	machine := chip8.NewChip8()
+	r := NewFromConfig(&machine, Config{Palette: Palette{bg, fg}, GhostTrailFrames: 3})
+
+	frame := make([]bool, machine.ScreenWidth()*machine.ScreenHeight())
+	img := image.NewRGBA(image.Rect(0, 0, machine.ScreenWidth(), machine.ScreenHeight()))
+	draw := func(on bool) color.RGBA {
+		frame[0] = on
+		r.drawScreen(img, 0, 0, frame)
+		return img.RGBAAt(0, 0)
+	}
+
+	require.Equal(t, fg, draw(true))
+
+	// the cleared pixel fades out over the trail frames
+	prev := fg
+	for i := 0; i < 3; i++ {
+		c := draw(false)
+		require.Less(t, c.R, prev.R, "frame %d after the clear", i)
+		require.Greater(t, c.R, bg.R, "frame %d after the clear", i)
+		prev = c
+	}
+	require.Equal(t, bg, draw(false))
+
+	// pixels that were never on stay dark
+	require.Equal(t, bg, img.RGBAAt(1, 0))
+}
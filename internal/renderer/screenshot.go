@@ -0,0 +1,48 @@
+package renderer
+
+import (
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// screenshots are scaled up like the screen copied to the clipboard by default
+const defaultScreenshotScale = clipboardScale
+
+// SaveScreenshot writes the last drawn screen to a PNG file, colored by the palette
+// and with every pixel scaled to a square of scale size.
+func (r *Renderer) SaveScreenshot(path string, scale int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("couldn't create the screenshot file: %w", err)
+	}
+
+	err = png.Encode(f, r.chip8.CaptureFrameImage(r.palette.Color(1), r.palette.Color(0), scale))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't write the screenshot: %w", err)
+	}
+	return nil
+}
+
+// saveTimestampedScreenshot writes the screenshot to the screenshot directory
+// and returns the path of the file.
+func (r *Renderer) saveTimestampedScreenshot() (string, error) {
+	path := filepath.Join(r.screenshotDir, captureName(r.chip8.GetRomName(), time.Now(), ".png"))
+	return path, r.SaveScreenshot(path, r.screenshotScale)
+}
+
+// captureName returns the file name with the extension of the screenshot or the recording
+// of the rom taken at t, e.g. pong-20240102-150405.000.png. Captures taken in a row get different names.
+func captureName(romName string, t time.Time, ext string) string {
+	name := strings.TrimSuffix(filepath.Base(romName), filepath.Ext(romName))
+	if len(name) == 0 || name == "." {
+		name = "chip8"
+	}
+	return name + "-" + t.Format("20060102-150405.000") + ext
+}
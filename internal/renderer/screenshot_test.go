@@ -0,0 +1,62 @@
+package renderer
+
+import (
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_SaveScreenshot(t *testing.T) {
+	t.Parallel()
+
+	fgColor := color.RGBA{R: 0x65, G: 0xf0, B: 0x57, A: 0xff}
+	bgColor := color.RGBA{B: 0x40, A: 0xff}
+
+	machine := chip8.NewChip8()
+	machine.LoadRom(chip8.Rom{
+		Data: []byte{
+			0xa0, 0x00, // vI = 0x000, font sprite of 0
+			0xd0, 0x05, // draw(0, 0, 5)
+		},
+	})
+	machine.Emulate()
+	machine.Emulate()
+
+	r := &Renderer{
+		chip8:   &machine,
+		palette: Palette{bgColor, fgColor},
+	}
+
+	const scale = 3
+	path := filepath.Join(t.TempDir(), "shot.png")
+	require.NoError(t, r.SaveScreenshot(path, scale))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	img, err := png.Decode(f)
+	require.NoError(t, err)
+
+	w, h := machine.ScreenSize()
+	require.Equal(t, w*scale, img.Bounds().Dx())
+	require.Equal(t, h*scale, img.Bounds().Dy())
+
+	// the top left pixel of 0 is on, the second pixel of its second row is off
+	require.Equal(t, fgColor, color.RGBAModel.Convert(img.At(0, 0)))
+	require.Equal(t, fgColor, color.RGBAModel.Convert(img.At(scale-1, scale-1)))
+	require.Equal(t, bgColor, color.RGBAModel.Convert(img.At(scale, scale)))
+}
+
+func TestCaptureName(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 2, 15, 4, 5, 6e6, time.UTC)
+	require.Equal(t, "pong-20240102-150405.006.png", captureName("roms/pong.ch8", at, ".png"))
+	require.Equal(t, "chip8-20240102-150405.006.gif", captureName("", at, ".gif"))
+}
@@ -0,0 +1,18 @@
+package renderer
+
+import "github.com/nevisdale/go-chip8/internal/chip8"
+
+// throttledTPS returns the update rate for the current machine activity.
+// The rate is lowered to idleTPS while the machine is paused or halted, or jumps to itself with the timers stopped,
+// and it's restored as soon as the machine is active again. Zero idleTPS disables throttling.
+// A machine waiting for a key isn't throttled, the keys are polled every update and a short tap would be lost,
+// neither are running timers, they'd count down slower than 60 Hz.
+func throttledTPS(tps, idleTPS int, state chip8.State, idle, timersRunning bool) int {
+	if idleTPS <= 0 || idleTPS >= tps {
+		return tps
+	}
+	if state != chip8.StateRunning || idle && !timersRunning {
+		return idleTPS
+	}
+	return tps
+}
@@ -0,0 +1,51 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottledTPS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		idleTPS       int
+		state         chip8.State
+		idle          bool
+		timersRunning bool
+		expected      int
+	}{
+		{"running", 10, chip8.StateRunning, false, false, 60},
+		{"paused", 10, chip8.StatePaused, false, false, 10},
+		{"halted", 10, chip8.StateHalted, false, false, 10},
+		{"self jump", 10, chip8.StateRunning, true, false, 10},
+		{"self jump with timers", 10, chip8.StateRunning, true, true, 60},
+		{"timers", 10, chip8.StateRunning, false, true, 60},
+		{"disabled", 0, chip8.StatePaused, true, false, 60},
+		{"idle rate is higher", 100, chip8.StatePaused, false, false, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, throttledTPS(60, tt.idleTPS, tt.state, tt.idle, tt.timersRunning))
+		})
+	}
+}
+
+func TestRenderer_ThrottleKeyWait(t *testing.T) {
+	t.Parallel()
+
+	machine := chip8.NewChip8()
+	machine.LoadRom(chip8.Rom{
+		Data: []byte{
+			0xf0, 0x0a, // 0x200: wait for a key
+		},
+	})
+	r := NewFromConfig(&machine, Config{IdleTPS: 10})
+
+	r.emulateFrame()
+	require.True(t, machine.IsWaitingForKey())
+	require.Equal(t, chip8.FramesPerSecond, r.updateTPS(), "keys are polled at the full rate")
+}
@@ -0,0 +1,58 @@
+package renderer
+
+import "image/color"
+
+// ghostTrail keeps erased pixels glowing for a number of frames,
+// so fast sprites that are redrawn every frame leave a fading trail instead of flickering out.
+// Only a pixel that was on and is erased starts a trail, pixels that were never lit stay dark.
+type ghostTrail struct {
+	// frames a trail lasts after the erase. 0 disables the trail
+	frames int
+
+	// frames left till every pixel is dark, frames+1 while the pixel is on
+	fade []int
+}
+
+func newGhostTrail(frames, pixels int) ghostTrail {
+	if frames < 0 {
+		frames = 0
+	}
+	return ghostTrail{
+		frames: frames,
+		fade:   make([]int, pixels),
+	}
+}
+
+// update advances the pixel by a frame and returns its intensity from 0 (dark) to 1 (on).
+func (t *ghostTrail) update(i int, on bool) float64 {
+	if on {
+		t.fade[i] = t.frames + 1
+		return 1
+	}
+	if t.fade[i] > 0 {
+		t.fade[i]--
+	}
+	return float64(t.fade[i]) / float64(t.frames+1)
+}
+
+// blendColor mixes bg and fg in proportion to the intensity of fg.
+func blendColor(bg, fg color.Color, intensity float64) color.Color {
+	switch {
+	case intensity <= 0:
+		return bg
+	case intensity >= 1:
+		return fg
+	}
+
+	br, bgG, bb, ba := bg.RGBA()
+	fr, fgG, fb, fa := fg.RGBA()
+	mix := func(b, f uint32) uint8 {
+		return uint8((float64(b) + (float64(f)-float64(b))*intensity) / 0x101)
+	}
+	return color.RGBA{
+		R: mix(br, fr),
+		G: mix(bgG, fgG),
+		B: mix(bb, fb),
+		A: mix(ba, fa),
+	}
+}
@@ -0,0 +1,58 @@
+package renderer
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGhostTrail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("erased pixel fades out", func(t *testing.T) {
+		trail := newGhostTrail(3, 1)
+
+		require.Equal(t, 1.0, trail.update(0, true))
+
+		// the pixel is erased and stays off
+		require.Equal(t, 0.75, trail.update(0, false))
+		require.Equal(t, 0.5, trail.update(0, false))
+		require.Equal(t, 0.25, trail.update(0, false))
+		require.Equal(t, 0.0, trail.update(0, false))
+		require.Equal(t, 0.0, trail.update(0, false))
+	})
+
+	t.Run("redrawn pixel restarts the trail", func(t *testing.T) {
+		trail := newGhostTrail(3, 1)
+
+		trail.update(0, true)
+		trail.update(0, false)
+		trail.update(0, false)
+		require.Equal(t, 1.0, trail.update(0, true))
+		require.Equal(t, 0.75, trail.update(0, false))
+	})
+
+	t.Run("never lit pixel", func(t *testing.T) {
+		trail := newGhostTrail(3, 1)
+		require.Equal(t, 0.0, trail.update(0, false))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		trail := newGhostTrail(0, 1)
+
+		require.Equal(t, 1.0, trail.update(0, true))
+		require.Equal(t, 0.0, trail.update(0, false))
+	})
+}
+
+func TestBlendColor(t *testing.T) {
+	t.Parallel()
+
+	bg := color.RGBA{A: 0xff}
+	fg := color.RGBA{R: 0xff, G: 0x80, A: 0xff}
+
+	require.Equal(t, bg, blendColor(bg, fg, 0))
+	require.Equal(t, fg, blendColor(bg, fg, 1))
+	require.Equal(t, color.RGBA{R: 0x7f, G: 0x40, A: 0xff}, blendColor(bg, fg, 0.5))
+}
@@ -0,0 +1,32 @@
+package renderer
+
+// the emulation runs 4 times faster while the turbo key is held by default
+const defaultTurboMultiplier = 4
+
+// turbo multiplies the instructions of an update while the turbo key is held
+type turbo struct {
+	multiplier int
+	held       bool
+}
+
+func newTurbo(multiplier int) turbo {
+	if multiplier < 1 {
+		multiplier = defaultTurboMultiplier
+	}
+	return turbo{multiplier: multiplier}
+}
+
+// update records whether the turbo key is held and reports whether it was pressed or released.
+func (t *turbo) update(held bool) bool {
+	changed := t.held != held
+	t.held = held
+	return changed
+}
+
+// instructions returns the instructions of an update at the normal speed scaled by the turbo.
+func (t turbo) instructions(n int) int {
+	if t.held {
+		return n * t.multiplier
+	}
+	return n
+}
@@ -0,0 +1,33 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTurbo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pressed and released", func(t *testing.T) {
+		turbo := newTurbo(3)
+		require.Equal(t, 2, turbo.instructions(2), "normal speed")
+
+		require.True(t, turbo.update(true), "pressed")
+		require.Equal(t, 6, turbo.instructions(2))
+
+		require.False(t, turbo.update(true), "held")
+		require.Equal(t, 6, turbo.instructions(2))
+
+		require.True(t, turbo.update(false), "released")
+		require.Equal(t, 2, turbo.instructions(2))
+
+		require.False(t, turbo.update(false))
+	})
+
+	t.Run("default multiplier", func(t *testing.T) {
+		turbo := newTurbo(0)
+		turbo.update(true)
+		require.Equal(t, defaultTurboMultiplier, turbo.instructions(1))
+	})
+}
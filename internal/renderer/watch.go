@@ -0,0 +1,51 @@
+package renderer
+
+import (
+	"io/fs"
+	"os"
+	"time"
+)
+
+// how often the rom file is checked for changes
+const romWatchInterval = 500 * time.Millisecond
+
+// romWatcher polls the modification time of the rom file,
+// so a rom rebuilt by an external assembler is reloaded without restarting the emulator.
+type romWatcher struct {
+	path string
+	stat func(path string) (fs.FileInfo, error)
+
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+func newRomWatcher(path string) *romWatcher {
+	w := &romWatcher{
+		path: path,
+		stat: os.Stat,
+	}
+	if info, err := w.stat(path); err == nil {
+		w.modTime = info.ModTime()
+	}
+	return w
+}
+
+// changed reports whether the rom file was modified since the last change.
+// The file is checked at most once per romWatchInterval. A missing file, e.g. in the middle
+// of a rebuild, isn't a change.
+func (w *romWatcher) changed(now time.Time) bool {
+	if now.Sub(w.lastCheck) < romWatchInterval {
+		return false
+	}
+	w.lastCheck = now
+
+	info, err := w.stat(w.path)
+	if err != nil {
+		return false
+	}
+	if info.ModTime().Equal(w.modTime) {
+		return false
+	}
+	w.modTime = info.ModTime()
+	return true
+}
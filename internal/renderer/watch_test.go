@@ -0,0 +1,57 @@
+package renderer
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFileInfo struct {
+	fs.FileInfo
+	modTime time.Time
+}
+
+func (f fakeFileInfo) ModTime() time.Time {
+	return f.modTime
+}
+
+func TestRomWatcher(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(0, 0)
+
+	modTime := start
+	var statErr error
+	w := &romWatcher{
+		path: "rom.ch8",
+		stat: func(string) (fs.FileInfo, error) {
+			return fakeFileInfo{modTime: modTime}, statErr
+		},
+		modTime: modTime,
+	}
+
+	now := start.Add(time.Minute)
+	require.False(t, w.changed(now), "the file isn't modified")
+
+	// the rom is rebuilt
+	modTime = modTime.Add(time.Second)
+	require.False(t, w.changed(now.Add(romWatchInterval/2)), "too early to check")
+
+	now = now.Add(romWatchInterval)
+	require.True(t, w.changed(now))
+
+	now = now.Add(romWatchInterval)
+	require.False(t, w.changed(now), "the change is reported once")
+
+	// the file is removed in the middle of a rebuild
+	statErr = fs.ErrNotExist
+	now = now.Add(romWatchInterval)
+	require.False(t, w.changed(now))
+
+	statErr = nil
+	modTime = modTime.Add(time.Second)
+	now = now.Add(romWatchInterval)
+	require.True(t, w.changed(now))
+}
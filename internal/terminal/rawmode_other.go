@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package terminal
+
+import "errors"
+
+var errRawModeUnsupported = errors.New("raw terminal mode is not supported on this platform")
+
+func makeRaw(int) (func(), error) {
+	return nil, errRawModeUnsupported
+}
@@ -0,0 +1,216 @@
+package terminal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+)
+
+// ====================
+// keyboard key mapping
+// ====================
+//
+//	1 2 3 C  -> 1 2 3 4
+//	4 5 6 D  -> Q W E R
+//	7 8 9 E  -> A S D F
+//	A 0 B F  -> Z X C V
+var keyboardMapping = map[byte]uint8{
+	'1': 0x1, '2': 0x2, '3': 0x3, '4': 0xC,
+	'q': 0x4, 'w': 0x5, 'e': 0x6, 'r': 0xD,
+	'a': 0x7, 's': 0x8, 'd': 0x9, 'f': 0xE,
+	'z': 0xA, 'x': 0x0, 'c': 0xB, 'v': 0xF,
+}
+
+const (
+	// keys of the CHIP8 keypad
+	keypadKeys = 16
+	// terminals report key presses only, a key is released when it isn't repeated for the frames
+	keyHoldFrames = 8
+
+	keyEscape = 0x1b
+	keyCtrlC  = 0x03
+
+	// ANSI escape sequences
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+	clearScreen = "\x1b[2J"
+	cursorHome  = "\x1b[H"
+)
+
+// ErrNotATerminal is returned when the input of the terminal renderer isn't a terminal.
+var ErrNotATerminal = errors.New("input is not a terminal")
+
+// Terminal draws the CHIP8 screen to a terminal with half blocks, two pixel rows per line,
+// and reads the keypad from the keyboard. Escape or Ctrl+C quits, P pauses the game.
+type Terminal struct {
+	chip8 *chip8.Chip8
+	in    *os.File
+	out   io.Writer
+
+	// frames left until a key is released
+	held  [keypadKeys]int
+	frame []bool
+}
+
+// New returns a terminal renderer of the machine reading the keyboard from in and drawing to out.
+func New(chip8 *chip8.Chip8, in *os.File, out io.Writer) *Terminal {
+	return &Terminal{
+		chip8: chip8,
+		in:    in,
+		out:   out,
+	}
+}
+
+// Run puts the terminal in raw mode and emulates the machine until it quits or the user exits.
+// The terminal is restored on return.
+func (t *Terminal) Run() error {
+	restore, err := makeRaw(int(t.in.Fd()))
+	if err != nil {
+		return fmt.Errorf("run terminal: %w", err)
+	}
+	defer restore()
+
+	fmt.Fprint(t.out, hideCursor+clearScreen)
+	defer fmt.Fprint(t.out, showCursor+"\r\n")
+
+	keys := make(chan byte, 64)
+	go readKeys(t.in, keys)
+
+	ticker := time.NewTicker(time.Second / chip8.FramesPerSecond)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !t.update(keys) {
+			return nil
+		}
+		if err := t.draw(); err != nil {
+			return fmt.Errorf("run terminal: %w", err)
+		}
+	}
+	return nil
+}
+
+// update handles the keys pressed since the last frame and runs the instructions of a frame.
+// It returns false once the game is over.
+func (t *Terminal) update(keys <-chan byte) bool {
+	for i := range t.held {
+		if t.held[i] > 0 {
+			t.held[i]--
+		}
+	}
+
+	for drained := false; !drained; {
+		select {
+		case key := <-keys:
+			switch key {
+			case keyEscape, keyCtrlC:
+				return false
+			case 'p', 'P':
+				t.chip8.TogglePause()
+			}
+			if chip8Key, ok := keyboardMapping[toLower(key)]; ok {
+				t.held[chip8Key] = keyHoldFrames
+			}
+		default:
+			drained = true
+		}
+	}
+
+	for chip8Key, frames := range t.held {
+		// the keys are in the keypad range
+		_ = t.chip8.SetKey(uint8(chip8Key), frames > 0)
+	}
+
+	for i := 0; i < t.chip8.CyclesPerFrame(); i++ {
+		if err := t.chip8.Emulate(); err != nil {
+			break
+		}
+	}
+	return t.chip8.GetState() != chip8.StateQuit
+}
+
+// draw writes the screen over the previous frame
+func (t *Terminal) draw() error {
+	width, height := t.chip8.ScreenSize()
+	if len(t.frame) != width*height {
+		t.frame = make([]bool, width*height)
+		// clear the bigger frame of the previous resolution
+		fmt.Fprint(t.out, clearScreen)
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			t.frame[y*width+x] = t.chip8.ScreenPixelSetAt(x, y)
+		}
+	}
+	t.chip8.EndFrame()
+
+	var sb strings.Builder
+	sb.WriteString(cursorHome)
+	for _, line := range frameToCells(t.frame, width, height) {
+		sb.WriteString(line)
+		// the new line doesn't return the carriage in raw mode
+		sb.WriteString("\r\n")
+	}
+	sb.WriteString(t.statusLine())
+
+	_, err := io.WriteString(t.out, sb.String())
+	return err
+}
+
+func (t *Terminal) statusLine() string {
+	status := "CHIP8 Emulator: " + t.chip8.GetRomName() + " " + t.chip8.GetState().String()
+	if err := t.chip8.Err(); err != nil {
+		status += ": " + err.Error()
+	}
+	// erase the rest of the previous status
+	return status + "\x1b[K"
+}
+
+// frameToCells converts the frame to lines of half blocks, a line covers two pixel rows.
+// The last line of an odd height has only the upper half.
+func frameToCells(frame []bool, width, height int) []string {
+	lines := make([]string, 0, (height+1)/2)
+	for y := 0; y < height; y += 2 {
+		var sb strings.Builder
+		for x := 0; x < width; x++ {
+			upper := frame[y*width+x]
+			lower := y+1 < height && frame[(y+1)*width+x]
+			switch {
+			case upper && lower:
+				sb.WriteRune('█')
+			case upper:
+				sb.WriteRune('▀')
+			case lower:
+				sb.WriteRune('▄')
+			default:
+				sb.WriteByte(' ')
+			}
+		}
+		lines = append(lines, sb.String())
+	}
+	return lines
+}
+
+// readKeys sends the bytes read from the input until it fails
+func readKeys(in io.Reader, keys chan<- byte) {
+	r := bufio.NewReader(in)
+	for {
+		key, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		keys <- key
+	}
+}
+
+func toLower(key byte) byte {
+	if key >= 'A' && key <= 'Z' {
+		return key + 'a' - 'A'
+	}
+	return key
+}
@@ -0,0 +1,72 @@
+package terminal
+
+import (
+	"io"
+	"testing"
+
+	"github.com/nevisdale/go-chip8/internal/chip8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameToCells(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pairs the pixel rows", func(t *testing.T) {
+		frame := []bool{
+			true, true, false, false,
+			true, false, true, false,
+		}
+
+		require.Equal(t, []string{"█▀▄ "}, frameToCells(frame, 4, 2))
+	})
+
+	t.Run("odd height", func(t *testing.T) {
+		frame := []bool{
+			true, false,
+			false, true,
+			true, false,
+		}
+
+		require.Equal(t, []string{"▀▄", "▀ "}, frameToCells(frame, 2, 3))
+	})
+
+	t.Run("empty frame", func(t *testing.T) {
+		lines := frameToCells(make([]bool, 64*32), 64, 32)
+
+		require.Len(t, lines, 16)
+		for _, line := range lines {
+			require.Len(t, line, 64)
+		}
+	})
+}
+
+func TestTerminal_Update(t *testing.T) {
+	t.Parallel()
+
+	t.Run("holds the pressed key", func(t *testing.T) {
+		machine := chip8.NewChip8()
+		term := New(&machine, nil, io.Discard)
+		keys := make(chan byte, 1)
+
+		keys <- 'W'
+		require.True(t, term.update(keys))
+		require.True(t, machine.KeyIsPressed(0x5))
+
+		for i := 1; i < keyHoldFrames; i++ {
+			require.True(t, term.update(keys))
+		}
+		require.True(t, machine.KeyIsPressed(0x5))
+
+		require.True(t, term.update(keys))
+		require.False(t, machine.KeyIsPressed(0x5))
+	})
+
+	t.Run("quits on escape", func(t *testing.T) {
+		machine := chip8.NewChip8()
+		term := New(&machine, nil, io.Discard)
+		keys := make(chan byte, 1)
+
+		keys <- keyEscape
+		require.False(t, term.update(keys))
+	})
+}